@@ -0,0 +1,318 @@
+package seq
+
+import (
+	"cmp"
+	"iter"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Accumulator holds the running state of a single aggregation.
+type Accumulator[V, R any] interface {
+	// Add accumulates a value.
+	Add(v V)
+
+	// Result returns the result for the values accumulated so far.
+	Result() R
+}
+
+// Aggregator describes an aggregation that is computed in a single pass over a sequence.
+//
+// Calling an Aggregator returns a new, independent [Accumulator], so the same Aggregator
+// can be used for many sequences or for each group of [AggregateGroupedWith].
+//
+// Example:
+//
+//	type stats struct{ count, sum int }
+//
+//	// count and sum in a single pass
+//	s := seq.AggregateWith(vals, seq.Combine2(
+//		seq.CountAggregator[int](),
+//		seq.SumAggregator[int](),
+//		func(count, sum int) stats { return stats{count, sum} },
+//	))
+type Aggregator[V, R any] func() Accumulator[V, R]
+
+// NewAggregator creates an aggregator from a function that creates the initial accumulated value,
+// an accumulator function and a function that computes the result from the accumulated value.
+func NewAggregator[V, A, R any](init func() A, f func(A, V) A, result func(A) R) Aggregator[V, R] {
+	return func() Accumulator[V, R] {
+		return &funcAccumulator[V, A, R]{acc: init(), f: f, result: result}
+	}
+}
+
+// funcAccumulator is an [Accumulator] backed by functions.
+type funcAccumulator[V, A, R any] struct {
+	acc    A
+	f      func(A, V) A
+	result func(A) R
+}
+
+func (a *funcAccumulator[V, A, R]) Add(v V) {
+	a.acc = a.f(a.acc, v)
+}
+
+func (a *funcAccumulator[V, A, R]) Result() R {
+	return a.result(a.acc)
+}
+
+// identity returns its argument.
+func identity[V any](v V) V {
+	return v
+}
+
+// AggregateWith computes an aggregation over a sequence.
+func AggregateWith[V, R any](seq iter.Seq[V], agg Aggregator[V, R]) R {
+	acc := agg()
+	for v := range seq {
+		acc.Add(v)
+	}
+
+	return acc.Result()
+}
+
+// CountAggregator returns an aggregator that counts values.
+func CountAggregator[V any]() Aggregator[V, int] {
+	return NewAggregator(
+		func() int { return 0 },
+		func(count int, _ V) int { return count + 1 },
+		identity[int],
+	)
+}
+
+// SumAggregator returns an aggregator that sums values.
+func SumAggregator[V constraints.Integer | constraints.Float]() Aggregator[V, V] {
+	return NewAggregator(
+		func() V { return 0 },
+		func(sum, v V) V { return sum + v },
+		identity[V],
+	)
+}
+
+// AverageAggregator returns an aggregator that averages values.
+//
+// The result is 0 if there were no values.
+func AverageAggregator[V constraints.Integer | constraints.Float]() Aggregator[V, float64] {
+	type state struct {
+		sum   V
+		count int
+	}
+
+	return NewAggregator(
+		func() state { return state{} },
+		func(s state, v V) state { return state{sum: s.sum + v, count: s.count + 1} },
+		func(s state) float64 {
+			if s.count == 0 {
+				return 0
+			}
+
+			return float64(s.sum) / float64(s.count)
+		},
+	)
+}
+
+// optional is a value that may not be present.
+type optional[V any] struct {
+	val V
+	ok  bool
+}
+
+func (o optional[V]) value() V {
+	return o.val
+}
+
+// MinAggregator returns an aggregator that finds the minimum value.
+//
+// The result is the zero value if there were no values.
+func MinAggregator[V cmp.Ordered]() Aggregator[V, V] {
+	return NewAggregator(
+		func() optional[V] { return optional[V]{} },
+		func(m optional[V], v V) optional[V] {
+			if !m.ok || v < m.val {
+				return optional[V]{val: v, ok: true}
+			}
+
+			return m
+		},
+		optional[V].value,
+	)
+}
+
+// MaxAggregator returns an aggregator that finds the maximum value.
+//
+// The result is the zero value if there were no values.
+func MaxAggregator[V cmp.Ordered]() Aggregator[V, V] {
+	return NewAggregator(
+		func() optional[V] { return optional[V]{} },
+		func(m optional[V], v V) optional[V] {
+			if !m.ok || v > m.val {
+				return optional[V]{val: v, ok: true}
+			}
+
+			return m
+		},
+		optional[V].value,
+	)
+}
+
+// FirstAggregator returns an aggregator that keeps the first value.
+//
+// The result is the zero value if there were no values.
+func FirstAggregator[V any]() Aggregator[V, V] {
+	return NewAggregator(
+		func() optional[V] { return optional[V]{} },
+		func(first optional[V], v V) optional[V] {
+			if first.ok {
+				return first
+			}
+
+			return optional[V]{val: v, ok: true}
+		},
+		optional[V].value,
+	)
+}
+
+// LastAggregator returns an aggregator that keeps the last value.
+//
+// The result is the zero value if there were no values.
+func LastAggregator[V any]() Aggregator[V, V] {
+	return NewAggregator(
+		func() V {
+			var zero V
+			return zero
+		},
+		func(_, v V) V { return v },
+		identity[V],
+	)
+}
+
+// ToSliceAggregator returns an aggregator that collects values into a slice.
+func ToSliceAggregator[V any]() Aggregator[V, []V] {
+	return NewAggregator(
+		func() []V { return make([]V, 0) },
+		func(s []V, v V) []V { return append(s, v) },
+		identity[[]V],
+	)
+}
+
+// DistinctAggregator returns an aggregator that collects distinct values into a slice
+// in order of first occurrence.
+func DistinctAggregator[V comparable]() Aggregator[V, []V] {
+	type state struct {
+		seen Set[V]
+		vals []V
+	}
+
+	return NewAggregator(
+		func() *state { return &state{seen: NewSet[V](), vals: make([]V, 0)} },
+		func(s *state, v V) *state {
+			if s.seen.Add(v) {
+				s.vals = append(s.vals, v)
+			}
+
+			return s
+		},
+		func(s *state) []V { return s.vals },
+	)
+}
+
+// Combine2 returns an aggregator that computes two aggregations in a single pass
+// and combines their results with a function.
+func Combine2[V, R1, R2, R any](
+	agg1 Aggregator[V, R1],
+	agg2 Aggregator[V, R2],
+	f func(R1, R2) R,
+) Aggregator[V, R] {
+	return func() Accumulator[V, R] {
+		acc1, acc2 := agg1(), agg2()
+
+		return &combinedAccumulator[V, R]{
+			accs:   []adder[V]{acc1, acc2},
+			result: func() R { return f(acc1.Result(), acc2.Result()) },
+		}
+	}
+}
+
+// Combine3 returns an aggregator that computes three aggregations in a single pass
+// and combines their results with a function.
+func Combine3[V, R1, R2, R3, R any](
+	agg1 Aggregator[V, R1],
+	agg2 Aggregator[V, R2],
+	agg3 Aggregator[V, R3],
+	f func(R1, R2, R3) R,
+) Aggregator[V, R] {
+	return func() Accumulator[V, R] {
+		acc1, acc2, acc3 := agg1(), agg2(), agg3()
+
+		return &combinedAccumulator[V, R]{
+			accs:   []adder[V]{acc1, acc2, acc3},
+			result: func() R { return f(acc1.Result(), acc2.Result(), acc3.Result()) },
+		}
+	}
+}
+
+// Combine4 returns an aggregator that computes four aggregations in a single pass
+// and combines their results with a function.
+func Combine4[V, R1, R2, R3, R4, R any](
+	agg1 Aggregator[V, R1],
+	agg2 Aggregator[V, R2],
+	agg3 Aggregator[V, R3],
+	agg4 Aggregator[V, R4],
+	f func(R1, R2, R3, R4) R,
+) Aggregator[V, R] {
+	return func() Accumulator[V, R] {
+		acc1, acc2, acc3, acc4 := agg1(), agg2(), agg3(), agg4()
+
+		return &combinedAccumulator[V, R]{
+			accs:   []adder[V]{acc1, acc2, acc3, acc4},
+			result: func() R { return f(acc1.Result(), acc2.Result(), acc3.Result(), acc4.Result()) },
+		}
+	}
+}
+
+// CombineAll returns an aggregator that computes several aggregations of the same result type
+// in a single pass. The results are returned in the same order as the aggregators.
+func CombineAll[V, R any](aggs ...Aggregator[V, R]) Aggregator[V, []R] {
+	return func() Accumulator[V, []R] {
+		accs := make([]Accumulator[V, R], len(aggs))
+		adders := make([]adder[V], len(aggs))
+
+		for i, agg := range aggs {
+			accs[i] = agg()
+			adders[i] = accs[i]
+		}
+
+		return &combinedAccumulator[V, []R]{
+			accs: adders,
+			result: func() []R {
+				results := make([]R, len(accs))
+				for i, acc := range accs {
+					results[i] = acc.Result()
+				}
+
+				return results
+			},
+		}
+	}
+}
+
+// adder is the part of an [Accumulator] that does not depend on the result type.
+type adder[V any] interface {
+	Add(v V)
+}
+
+// combinedAccumulator feeds each value to several accumulators.
+type combinedAccumulator[V, R any] struct {
+	result func() R
+	accs   []adder[V]
+}
+
+func (a *combinedAccumulator[V, R]) Add(v V) {
+	for _, acc := range a.accs {
+		acc.Add(v)
+	}
+}
+
+func (a *combinedAccumulator[V, R]) Result() R {
+	return a.result()
+}
@@ -0,0 +1,124 @@
+package seq_test
+
+import (
+	"testing"
+
+	"github.com/arielsrv/go-seq"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AggregateWith(t *testing.T) {
+	tests := []struct {
+		name string
+		agg  seq.Aggregator[int, int]
+		vals []int
+		want int
+	}{
+		{name: "count", agg: seq.CountAggregator[int](), vals: []int{4, 5, 6}, want: 3},
+		{name: "count empty", agg: seq.CountAggregator[int](), vals: nil, want: 0},
+		{name: "sum", agg: seq.SumAggregator[int](), vals: []int{1, 2, 3}, want: 6},
+		{name: "min", agg: seq.MinAggregator[int](), vals: []int{3, 1, 2}, want: 1},
+		{name: "min empty", agg: seq.MinAggregator[int](), vals: nil, want: 0},
+		{name: "max", agg: seq.MaxAggregator[int](), vals: []int{1, 3, 2}, want: 3},
+		{name: "first", agg: seq.FirstAggregator[int](), vals: []int{7, 8, 9}, want: 7},
+		{name: "last", agg: seq.LastAggregator[int](), vals: []int{7, 8, 9}, want: 9},
+		{
+			name: "custom",
+			agg: seq.NewAggregator(
+				func() int { return 1 },
+				func(acc, v int) int { return acc * v },
+				func(acc int) int { return -acc },
+			),
+			vals: []int{2, 3, 4},
+			want: -24,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := seq.AggregateWith(seq.Yield(tt.vals...), tt.agg)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_AggregateWith_Slices(t *testing.T) {
+	vals := seq.Yield(3, 1, 3, 2, 1)
+
+	assert.Equal(t, []int{3, 1, 3, 2, 1}, seq.AggregateWith(vals, seq.ToSliceAggregator[int]()))
+	assert.Equal(t, []int{3, 1, 2}, seq.AggregateWith(vals, seq.DistinctAggregator[int]()))
+	assert.Equal(t, []int{}, seq.AggregateWith(seq.Empty[int](), seq.ToSliceAggregator[int]()))
+}
+
+func Test_AverageAggregator(t *testing.T) {
+	assert.InDelta(t, 2.5, seq.AggregateWith(seq.Yield(1, 2, 3, 4), seq.AverageAggregator[int]()), 1e-9)
+	assert.InDelta(t, 0, seq.AggregateWith(seq.Empty[int](), seq.AverageAggregator[int]()), 0)
+}
+
+func Test_Combine(t *testing.T) {
+	type stats struct {
+		count, sum, minVal, maxVal int
+	}
+
+	t.Run("single pass over a single-use sequence", func(t *testing.T) {
+		ch := make(chan int, 4)
+		ch <- 3
+		ch <- 1
+		ch <- 4
+		ch <- 2
+		close(ch)
+
+		got := seq.AggregateWith(seq.YieldChan(ch), seq.Combine4(
+			seq.CountAggregator[int](),
+			seq.SumAggregator[int](),
+			seq.MinAggregator[int](),
+			seq.MaxAggregator[int](),
+			func(count, sum, minVal, maxVal int) stats { return stats{count, sum, minVal, maxVal} },
+		))
+
+		assert.Equal(t, stats{count: 4, sum: 10, minVal: 1, maxVal: 4}, got)
+	})
+
+	t.Run("combine2", func(t *testing.T) {
+		got := seq.AggregateWith(seq.Yield(1, 2, 3), seq.Combine2(
+			seq.FirstAggregator[int](),
+			seq.LastAggregator[int](),
+			func(first, last int) [2]int { return [2]int{first, last} },
+		))
+
+		assert.Equal(t, [2]int{1, 3}, got)
+	})
+
+	t.Run("combine3", func(t *testing.T) {
+		got := seq.AggregateWith(seq.Yield(1, 2, 3), seq.Combine3(
+			seq.CountAggregator[int](),
+			seq.AverageAggregator[int](),
+			seq.ToSliceAggregator[int](),
+			func(count int, avg float64, vals []int) string {
+				return toString(count) + " " + toString(avg) + " " + toString(vals)
+			},
+		))
+
+		assert.Equal(t, "3 2 [1 2 3]", got)
+	})
+
+	t.Run("combine all", func(t *testing.T) {
+		got := seq.AggregateWith(seq.Yield(5, 1, 9), seq.CombineAll(
+			seq.MinAggregator[int](),
+			seq.MaxAggregator[int](),
+			seq.SumAggregator[int](),
+		))
+
+		assert.Equal(t, []int{1, 9, 15}, got)
+	})
+}
+
+func Test_Aggregator_Reusable(t *testing.T) {
+	agg := seq.ToSliceAggregator[int]()
+
+	first := seq.AggregateWith(seq.Yield(1, 2), agg)
+	second := seq.AggregateWith(seq.Yield(3), agg)
+
+	assert.Equal(t, []int{1, 2}, first)
+	assert.Equal(t, []int{3}, second)
+}
@@ -0,0 +1,138 @@
+package seq
+
+import (
+	"errors"
+	"iter"
+	"math"
+)
+
+// BloomFilter is a probabilistic set that uses a fixed amount of memory.
+//
+// A Bloom filter never reports a value that was added as missing, but it may report a value
+// that was never added as present, with a false positive rate chosen when the filter is created.
+type BloomFilter[V any] struct {
+	hash func(V) uint64
+	bits []uint64
+	m    uint64
+	k    int
+}
+
+// NewBloomFilter creates a Bloom filter sized for an expected number of values and a target
+// false positive rate.
+//
+// This panics if expectedN is not positive or fpRate is not between 0 and 1 (exclusive).
+func NewBloomFilter[V comparable](expectedN int, fpRate float64) *BloomFilter[V] {
+	return NewBloomFilterFunc(expectedN, fpRate, hashComparable[V])
+}
+
+// NewBloomFilterFunc creates a Bloom filter sized for an expected number of values and a target
+// false positive rate using a function to hash values.
+//
+// Filters can only be merged if they use the same hash function.
+// This panics if expectedN is not positive or fpRate is not between 0 and 1 (exclusive).
+func NewBloomFilterFunc[V any](expectedN int, fpRate float64, hash func(V) uint64) *BloomFilter[V] {
+	if expectedN <= 0 {
+		panic("seq.NewBloomFilter: expectedN must be positive")
+	}
+
+	if !(fpRate > 0 && fpRate < 1) {
+		panic("seq.NewBloomFilter: fpRate must be between 0 and 1")
+	}
+
+	// optimal number of bits and hash functions
+	m := math.Ceil(-float64(expectedN) * math.Log(fpRate) / (math.Ln2 * math.Ln2))
+	k := max(1, int(math.Round(m/float64(expectedN)*math.Ln2)))
+
+	words := (uint64(m) + 63) / 64
+
+	return &BloomFilter[V]{
+		hash: hash,
+		bits: make([]uint64, words),
+		m:    words * 64,
+		k:    k,
+	}
+}
+
+// Add adds a value to the filter.
+// Returns true if the value was added, false if it was possibly already present.
+func (b *BloomFilter[V]) Add(v V) bool {
+	added := false
+
+	for i := range b.indexes(v) {
+		word, mask := i/64, uint64(1)<<(i%64)
+		if b.bits[word]&mask == 0 {
+			b.bits[word] |= mask
+			added = true
+		}
+	}
+
+	return added
+}
+
+// Contains determines whether a value is possibly present in the filter.
+func (b *BloomFilter[V]) Contains(v V) bool {
+	for i := range b.indexes(v) {
+		if b.bits[i/64]&(uint64(1)<<(i%64)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Merge adds the values of another filter to this filter.
+//
+// Both filters must have been created with the same size, false positive rate and hash function.
+func (b *BloomFilter[V]) Merge(other *BloomFilter[V]) error {
+	if b.m != other.m || b.k != other.k {
+		return errors.New("seq.BloomFilter: cannot merge filters with different sizes")
+	}
+
+	for i, word := range other.bits {
+		b.bits[i] |= word
+	}
+
+	return nil
+}
+
+// indexes returns the bit indexes for a value using double hashing.
+func (b *BloomFilter[V]) indexes(v V) iter.Seq[uint64] {
+	return func(yield func(uint64) bool) {
+		h1 := mix64(b.hash(v))
+		h2 := mix64(h1) | 1
+
+		for i := range uint64(b.k) {
+			if !yield((h1 + i*h2) % b.m) {
+				return
+			}
+		}
+	}
+}
+
+// DistinctApprox returns distinct values from a sequence using a fixed amount of memory.
+//
+// Seen values are tracked with a [BloomFilter] sized for expectedN distinct values, so a
+// value that was not seen before is skipped with a probability of about fpRate.
+// Repeated values are never yielded more than once.
+// Use [Distinct] instead if every distinct value must be yielded.
+func DistinctApprox[V comparable](seq iter.Seq[V], expectedN int, fpRate float64) iter.Seq[V] {
+	return DistinctApproxFunc(seq, expectedN, fpRate, hashComparable[V])
+}
+
+// DistinctApproxFunc returns distinct values from a sequence using a fixed amount of memory
+// and a function to hash values.
+//
+// See [DistinctApprox] for details.
+func DistinctApproxFunc[V any](seq iter.Seq[V], expectedN int, fpRate float64, hash func(V) uint64) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		filter := NewBloomFilterFunc(expectedN, fpRate, hash)
+
+		for v := range seq {
+			if filter.Add(v) {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package seq_test
+
+import (
+	"hash/fnv"
+	"testing"
+
+	"github.com/arielsrv/go-seq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hashBytes hashes a byte slice with FNV-1a.
+func hashBytes(b []byte) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write(b)
+
+	return h.Sum64()
+}
+
+func Test_BloomFilter(t *testing.T) {
+	filter := seq.NewBloomFilter[int](1000, 0.01)
+
+	for i := range 1000 {
+		filter.Add(i)
+	}
+
+	// no false negatives
+	for i := range 1000 {
+		assert.True(t, filter.Contains(i))
+	}
+
+	falsePositives := seq.CountFunc(seq.Take(naturals(), 10000), func(i int) bool {
+		return filter.Contains(i + 1000)
+	})
+	assert.Less(t, falsePositives, 200)
+
+	assert.False(t, filter.Add(1), "value already present")
+}
+
+func Test_BloomFilter_Merge(t *testing.T) {
+	a := seq.NewBloomFilter[string](100, 0.01)
+	b := seq.NewBloomFilter[string](100, 0.01)
+
+	a.Add("a")
+	b.Add("b")
+
+	require.NoError(t, a.Merge(b))
+	assert.True(t, a.Contains("a"))
+	assert.True(t, a.Contains("b"))
+
+	assert.Error(t, a.Merge(seq.NewBloomFilter[string](100000, 0.01)))
+}
+
+func Test_NewBloomFilter_Panics(t *testing.T) {
+	assert.Panics(t, func() { seq.NewBloomFilter[int](0, 0.01) })
+	assert.Panics(t, func() { seq.NewBloomFilter[int](10, 0) })
+	assert.Panics(t, func() { seq.NewBloomFilter[int](10, 1) })
+}
+
+func Test_DistinctApprox(t *testing.T) {
+	tests := []struct {
+		name string
+		vals []int
+		want []int
+	}{
+		{name: "distinct values", vals: []int{1, 2, 2, 3, 1, 4}, want: []int{1, 2, 3, 4}},
+		{name: "all duplicates", vals: []int{5, 5, 5}, want: []int{5}},
+		{name: "empty", vals: nil, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := seq.ToSlice(seq.DistinctApprox(seq.Yield(tt.vals...), 100, 0.001))
+			assert.ElementsMatch(t, tt.want, got)
+		})
+	}
+
+	t.Run("bounded false positives", func(t *testing.T) {
+		vals := seq.Concat(seq.Take(naturals(), 10000), seq.Take(naturals(), 10000))
+		got := seq.Count(seq.DistinctApprox(vals, 10000, 0.01))
+		assert.InDelta(t, 10000, got, 200)
+	})
+}
+
+func Test_DistinctApproxFunc(t *testing.T) {
+	vals := seq.Yield([]byte("a"), []byte("b"), []byte("a"))
+
+	got := seq.ToSlice(seq.DistinctApproxFunc(vals, 10, 0.001, hashBytes))
+	assert.Equal(t, [][]byte{[]byte("a"), []byte("b")}, got)
+}
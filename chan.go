@@ -0,0 +1,132 @@
+package seq
+
+import (
+	"context"
+	"iter"
+)
+
+// ToChan iterates a sequence in a new goroutine and sends its values to the returned channel,
+// which has a buffer of the given size.
+//
+// The channel is closed once the sequence ends or the context is done. The consumer must either
+// receive every value or cancel the context, otherwise the goroutine is blocked forever.
+// This panics if buf is negative.
+func ToChan[V any](ctx context.Context, seq iter.Seq[V], buf int) <-chan V {
+	if buf < 0 {
+		panic("seq.ToChan: buf must be non-negative")
+	}
+
+	ch := make(chan V, buf)
+
+	go func() {
+		defer close(ch)
+
+		for v := range seq {
+			if ctx.Err() != nil {
+				return
+			}
+
+			select {
+			case ch <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// ToChanErr iterates a sequence of values and errors in a new goroutine and sends its values to
+// the first returned channel, which has a buffer of the given size.
+//
+// The sequence stops at the first non-nil error, which is sent to the second returned channel;
+// if the context is done first, its error is sent instead. Both channels are closed once the
+// sequence ends, so the error channel receives at most one error. The consumer must either
+// receive every value or cancel the context, otherwise the goroutine is blocked forever.
+// This panics if buf is negative.
+func ToChanErr[V any](ctx context.Context, seq iter.Seq2[V, error], buf int) (<-chan V, <-chan error) {
+	if buf < 0 {
+		panic("seq.ToChanErr: buf must be non-negative")
+	}
+
+	ch := make(chan V, buf)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(ch)
+
+		for v, err := range seq {
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			if ctx.Err() != nil {
+				errs <- ctx.Err()
+				return
+			}
+
+			select {
+			case ch <- v:
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return ch, errs
+}
+
+// Prefetch returns a sequence that iterates a sequence in a new goroutine, reading up to n values
+// ahead of the consumer.
+//
+// This lets a slow producer and a slow consumer work at the same time; values are yielded in
+// their original order. When the returned sequence stops, the given sequence is stopped the next
+// time it yields a value, and the returned sequence waits for it to return. If the given
+// sequence panics, the panic is raised again by the returned sequence once it has yielded the
+// values before the panic.
+// This panics if n is negative.
+func Prefetch[V any](seq iter.Seq[V], n int) iter.Seq[V] {
+	if n < 0 {
+		panic("seq.Prefetch: n must be non-negative")
+	}
+
+	return func(yield func(V) bool) {
+		ch := make(chan V, n)
+		quit := make(chan struct{})
+
+		var panicked goroutinePanic
+
+		go func() {
+			defer close(ch)
+			defer panicked.capture()
+
+			for v := range seq {
+				select {
+				case ch <- v:
+				case <-quit:
+					return
+				}
+			}
+		}()
+
+		defer func() {
+			close(quit)
+
+			// wait for the sequence to stop
+			for range ch {
+			}
+		}()
+
+		for v := range ch {
+			if !yield(v) {
+				return
+			}
+		}
+
+		panicked.repanic()
+	}
+}
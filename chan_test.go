@@ -0,0 +1,184 @@
+package seq_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/arielsrv/go-seq"
+	"github.com/stretchr/testify/assert"
+)
+
+// stoppable returns an infinite sequence and a channel that is closed once the sequence returns.
+func stoppable() (iter.Seq[int], <-chan struct{}) {
+	stopped := make(chan struct{})
+
+	return func(yield func(int) bool) {
+		defer close(stopped)
+
+		for v := range naturals() {
+			if !yield(v) {
+				return
+			}
+		}
+	}, stopped
+}
+
+func Test_ToChan(t *testing.T) {
+	t.Run("all values", func(t *testing.T) {
+		for _, buf := range []int{0, 1, 10} {
+			ch := seq.ToChan(context.Background(), seq.Yield(1, 2, 3), buf)
+			assert.Equal(t, []int{1, 2, 3}, seq.ToSlice(seq.YieldChan(ch)))
+		}
+	})
+
+	t.Run("empty sequence", func(t *testing.T) {
+		ch := seq.ToChan(context.Background(), seq.Empty[int](), 0)
+
+		_, ok := <-ch
+		assert.False(t, ok)
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		source, stopped := stoppable()
+
+		ch := seq.ToChan(ctx, source, 2)
+		assert.Equal(t, []int{0, 1, 2}, limitedCollector(seq.YieldChan(ch), 3))
+
+		cancel()
+		<-stopped
+
+		// the channel is closed after any buffered values
+		for range ch {
+		}
+	})
+
+	t.Run("context already done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		for range 100 {
+			ch := seq.ToChan(ctx, seq.Yield(1, 2, 3), 10)
+			assert.Empty(t, seq.ToSlice(seq.YieldChan(ch)), "no values are sent after the context is done")
+		}
+	})
+
+	assert.Panics(t, func() { seq.ToChan(context.Background(), seq.Yield(1), -1) })
+}
+
+func Test_ToChanErr(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	t.Run("all values", func(t *testing.T) {
+		source := seq.Zip(seq.Yield(1, 2, 3), seq.Repeat[error](nil, 3))
+		ch, errs := seq.ToChanErr(context.Background(), source, 1)
+
+		assert.Equal(t, []int{1, 2, 3}, seq.ToSlice(seq.YieldChan(ch)))
+		assert.Empty(t, seq.ToSlice(seq.YieldChan(errs)))
+	})
+
+	t.Run("stops at first error", func(t *testing.T) {
+		source := seq.Zip(seq.Yield(1, 2, 3, 4), seq.Yield(nil, nil, errBoom, errors.New("other")))
+		ch, errs := seq.ToChanErr(context.Background(), source, 0)
+
+		assert.Equal(t, []int{1, 2}, seq.ToSlice(seq.YieldChan(ch)))
+		assert.Equal(t, []error{errBoom}, seq.ToSlice(seq.YieldChan(errs)))
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		source, stopped := stoppable()
+
+		ch, errs := seq.ToChanErr(ctx, seq.Zip(source, seq.RepeatForever[error](nil)), 0)
+		assert.Equal(t, []int{0}, limitedCollector(seq.YieldChan(ch), 1))
+
+		cancel()
+		<-stopped
+
+		for range ch {
+		}
+
+		assert.Equal(t, []error{context.Canceled}, seq.ToSlice(seq.YieldChan(errs)))
+	})
+
+	t.Run("context already done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		for range 100 {
+			ch, errs := seq.ToChanErr(ctx, seq.Zip(seq.Yield(1, 2, 3), seq.Repeat[error](nil, 3)), 10)
+
+			assert.Empty(t, seq.ToSlice(seq.YieldChan(ch)), "no values are sent after the context is done")
+			assert.Equal(t, []error{context.Canceled}, seq.ToSlice(seq.YieldChan(errs)))
+		}
+	})
+
+	assert.Panics(t, func() { seq.ToChanErr(context.Background(), seq.Empty2[int, error](), -1) })
+}
+
+func Test_Prefetch(t *testing.T) {
+	t.Run("preserves order", func(t *testing.T) {
+		for _, n := range []int{0, 1, 100} {
+			got := seq.Prefetch(seq.Take(naturals(), 50), n)
+			assert.Equal(t, seq.ToSlice(seq.Take(naturals(), 50)), seq.ToSlice(got))
+		}
+	})
+
+	t.Run("reads ahead", func(t *testing.T) {
+		var produced atomic.Int32
+
+		source := seq.Select(seq.Take(naturals(), 10), func(v int) int {
+			produced.Add(1)
+			return v
+		})
+
+		for v := range seq.Prefetch(source, 3) {
+			if v == 0 {
+				// the producer fills the buffer while the consumer waits
+				assert.Eventually(t, func() bool { return produced.Load() >= 4 }, time.Second, time.Millisecond)
+			}
+		}
+
+		assert.Equal(t, int32(10), produced.Load())
+	})
+
+	t.Run("early return", func(t *testing.T) {
+		source, stopped := stoppable()
+
+		assert.Equal(t, []int{0, 1}, limitedCollector(seq.Prefetch(source, 5), 2))
+
+		select {
+		case <-stopped:
+		default:
+			assert.Fail(t, "the source was not stopped")
+		}
+	})
+
+	t.Run("panic is raised in consumer", func(t *testing.T) {
+		source := func(yield func(int) bool) {
+			yield(1)
+			yield(2)
+			panic("boom")
+		}
+
+		var got []int
+		assert.PanicsWithValue(t, "boom", func() {
+			for v := range seq.Prefetch(iter.Seq[int](source), 1) {
+				got = append(got, v)
+			}
+		})
+
+		assert.Equal(t, []int{1, 2}, got)
+	})
+
+	t.Run("single-use sequence", func(t *testing.T) {
+		got := seq.Prefetch(seq.YieldChan(sendAll("a", "b")), 1)
+		assert.Equal(t, []string{"a", "b"}, seq.ToSlice(got))
+	})
+
+	assert.Panics(t, func() { seq.Prefetch(seq.Yield(1), -1) })
+}
@@ -0,0 +1,202 @@
+package seq
+
+import (
+	"iter"
+	"strings"
+)
+
+// Collector describes a reduction of a sequence into a result in three steps:
+// Supply creates a new accumulated value, Accumulate adds each value to it
+// and Finish converts the accumulated value into the result.
+//
+// Collectors compose: [GroupingBy], [PartitioningBy], [Mapping] and [Filtering] take
+// a downstream collector that is applied to each group of values.
+//
+// Example:
+//
+//	// average salary per department and level
+//	avgs := seq.CollectWith(employees,
+//		seq.GroupingBy(func(e Employee) string { return e.Department },
+//			seq.GroupingBy(func(e Employee) int { return e.Level },
+//				seq.Mapping(func(e Employee) float64 { return e.Salary },
+//					seq.Aggregating(seq.AverageAggregator[float64]()),
+//				),
+//			),
+//		),
+//	)
+type Collector[V, A, R any] interface {
+	// Supply returns a new accumulated value.
+	Supply() A
+
+	// Accumulate adds a value to an accumulated value and returns the updated accumulated value.
+	Accumulate(acc A, v V) A
+
+	// Finish converts an accumulated value into the result.
+	Finish(acc A) R
+}
+
+// NewCollector creates a collector from its supply, accumulate and finish functions.
+func NewCollector[V, A, R any](supply func() A, accumulate func(A, V) A, finish func(A) R) Collector[V, A, R] {
+	return funcCollector[V, A, R]{supply: supply, accumulate: accumulate, finish: finish}
+}
+
+// funcCollector is a [Collector] backed by functions.
+type funcCollector[V, A, R any] struct {
+	supply     func() A
+	accumulate func(A, V) A
+	finish     func(A) R
+}
+
+func (c funcCollector[V, A, R]) Supply() A {
+	return c.supply()
+}
+
+func (c funcCollector[V, A, R]) Accumulate(acc A, v V) A {
+	return c.accumulate(acc, v)
+}
+
+func (c funcCollector[V, A, R]) Finish(acc A) R {
+	return c.finish(acc)
+}
+
+// CollectWith reduces a sequence with a collector.
+func CollectWith[V, A, R any](seq iter.Seq[V], c Collector[V, A, R]) R {
+	acc := c.Supply()
+	for v := range seq {
+		acc = c.Accumulate(acc, v)
+	}
+
+	return c.Finish(acc)
+}
+
+// Aggregating returns a collector that computes an aggregation.
+//
+// This allows the built-in aggregators, such as [AverageAggregator], to be used as
+// downstream collectors.
+func Aggregating[V, R any](agg Aggregator[V, R]) Collector[V, Accumulator[V, R], R] {
+	return NewCollector(
+		agg,
+		func(acc Accumulator[V, R], v V) Accumulator[V, R] {
+			acc.Add(v)
+			return acc
+		},
+		Accumulator[V, R].Result,
+	)
+}
+
+// GroupingBy returns a collector that groups values by a key and collects the values of each
+// group with a downstream collector.
+func GroupingBy[V any, K comparable, A, R any](
+	keyFunc func(V) K,
+	downstream Collector[V, A, R],
+) Collector[V, map[K]A, map[K]R] {
+	return NewCollector(
+		func() map[K]A { return make(map[K]A) },
+		func(groups map[K]A, v V) map[K]A {
+			k := keyFunc(v)
+
+			acc, ok := groups[k]
+			if !ok {
+				acc = downstream.Supply()
+			}
+
+			groups[k] = downstream.Accumulate(acc, v)
+
+			return groups
+		},
+		func(groups map[K]A) map[K]R {
+			results := make(map[K]R, len(groups))
+			for k, acc := range groups {
+				results[k] = downstream.Finish(acc)
+			}
+
+			return results
+		},
+	)
+}
+
+// PartitioningBy returns a collector that splits values by a predicate and collects the values
+// of each partition with a downstream collector.
+//
+// The result always contains both the true and false partitions.
+func PartitioningBy[V, A, R any](
+	f func(V) bool,
+	downstream Collector[V, A, R],
+) Collector[V, map[bool]A, map[bool]R] {
+	return NewCollector(
+		func() map[bool]A {
+			return map[bool]A{true: downstream.Supply(), false: downstream.Supply()}
+		},
+		func(parts map[bool]A, v V) map[bool]A {
+			k := f(v)
+			parts[k] = downstream.Accumulate(parts[k], v)
+
+			return parts
+		},
+		func(parts map[bool]A) map[bool]R {
+			return map[bool]R{true: downstream.Finish(parts[true]), false: downstream.Finish(parts[false])}
+		},
+	)
+}
+
+// Mapping returns a collector that projects each value into a new value before passing it to
+// a downstream collector.
+func Mapping[V, VOut, A, R any](f func(V) VOut, downstream Collector[VOut, A, R]) Collector[V, A, R] {
+	return NewCollector(
+		downstream.Supply,
+		func(acc A, v V) A { return downstream.Accumulate(acc, f(v)) },
+		downstream.Finish,
+	)
+}
+
+// Filtering returns a collector that only passes values that satisfy a predicate to a downstream
+// collector.
+func Filtering[V, A, R any](f func(V) bool, downstream Collector[V, A, R]) Collector[V, A, R] {
+	return NewCollector(
+		downstream.Supply,
+		func(acc A, v V) A {
+			if !f(v) {
+				return acc
+			}
+
+			return downstream.Accumulate(acc, v)
+		},
+		downstream.Finish,
+	)
+}
+
+// Joining returns a collector that concatenates strings with a separator between them.
+func Joining(sep string) Collector[string, []string, string] {
+	return NewCollector(
+		func() []string { return nil },
+		func(s []string, v string) []string { return append(s, v) },
+		func(s []string) string { return strings.Join(s, sep) },
+	)
+}
+
+// ToMapCollector returns a collector that collects values into a map using functions to select
+// the key and the value.
+//
+// If there are duplicate keys, the values are combined with a merge function.
+// If the merge function is nil, the last value for the key is kept.
+func ToMapCollector[V any, K comparable, VOut any](
+	keyFunc func(V) K,
+	valueFunc func(V) VOut,
+	merge func(VOut, VOut) VOut,
+) Collector[V, map[K]VOut, map[K]VOut] {
+	return NewCollector(
+		func() map[K]VOut { return make(map[K]VOut) },
+		func(m map[K]VOut, v V) map[K]VOut {
+			k, val := keyFunc(v), valueFunc(v)
+
+			if prev, ok := m[k]; ok && merge != nil {
+				val = merge(prev, val)
+			}
+
+			m[k] = val
+
+			return m
+		},
+		identity[map[K]VOut],
+	)
+}
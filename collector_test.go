@@ -0,0 +1,147 @@
+package seq_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/arielsrv/go-seq"
+	"github.com/stretchr/testify/assert"
+)
+
+type employee struct {
+	Name       string
+	Department string
+	Level      int
+	Salary     float64
+}
+
+var employees = []employee{
+	{Name: "ann", Department: "eng", Level: 1, Salary: 100},
+	{Name: "bob", Department: "eng", Level: 1, Salary: 120},
+	{Name: "cid", Department: "eng", Level: 2, Salary: 200},
+	{Name: "dee", Department: "ops", Level: 1, Salary: 90},
+}
+
+func Test_CollectWith_NestedGrouping(t *testing.T) {
+	got := seq.CollectWith(seq.Yield(employees...),
+		seq.GroupingBy(func(e employee) string { return e.Department },
+			seq.GroupingBy(func(e employee) int { return e.Level },
+				seq.Mapping(func(e employee) float64 { return e.Salary },
+					seq.Aggregating(seq.AverageAggregator[float64]()),
+				),
+			),
+		),
+	)
+
+	assert.Equal(t, map[string]map[int]float64{
+		"eng": {1: 110, 2: 200},
+		"ops": {1: 90},
+	}, got)
+}
+
+func Test_GroupingBy(t *testing.T) {
+	got := seq.CollectWith(seq.Yield("apple", "avocado", "banana"),
+		seq.GroupingBy(func(s string) byte { return s[0] }, seq.Joining(",")),
+	)
+
+	assert.Equal(t, map[byte]string{'a': "apple,avocado", 'b': "banana"}, got)
+
+	empty := seq.CollectWith(seq.Empty[string](),
+		seq.GroupingBy(func(s string) byte { return s[0] }, seq.Joining(",")),
+	)
+	assert.Empty(t, empty)
+}
+
+func Test_PartitioningBy(t *testing.T) {
+	tests := []struct {
+		name string
+		vals []int
+		want map[bool]int
+	}{
+		{name: "both partitions", vals: []int{1, 2, 3, 4, 6}, want: map[bool]int{true: 3, false: 2}},
+		{name: "only one partition", vals: []int{2, 4}, want: map[bool]int{true: 2, false: 0}},
+		{name: "empty", vals: nil, want: map[bool]int{true: 0, false: 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := seq.CollectWith(seq.Yield(tt.vals...),
+				seq.PartitioningBy(isEven, seq.Aggregating(seq.CountAggregator[int]())),
+			)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_Filtering(t *testing.T) {
+	got := seq.CollectWith(seq.Yield(employees...),
+		seq.GroupingBy(func(e employee) string { return e.Department },
+			seq.Filtering(func(e employee) bool { return e.Salary > 95 },
+				seq.Mapping(func(e employee) string { return e.Name }, seq.Joining("+")),
+			),
+		),
+	)
+
+	// groups are kept even if all their values are filtered out
+	assert.Equal(t, map[string]string{"eng": "ann+bob+cid", "ops": ""}, got)
+}
+
+func Test_Joining(t *testing.T) {
+	tests := []struct {
+		name string
+		vals []string
+		sep  string
+		want string
+	}{
+		{name: "joined", vals: []string{"a", "b", "c"}, sep: ", ", want: "a, b, c"},
+		{name: "single", vals: []string{"a"}, sep: ", ", want: "a"},
+		{name: "empty strings", vals: []string{"", ""}, sep: "-", want: "-"},
+		{name: "empty", vals: nil, sep: ", ", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := seq.CollectWith(seq.Yield(tt.vals...), seq.Joining(tt.sep))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_ToMapCollector(t *testing.T) {
+	t.Run("merge", func(t *testing.T) {
+		got := seq.CollectWith(seq.Yield(employees...),
+			seq.ToMapCollector(
+				func(e employee) string { return e.Department },
+				func(e employee) float64 { return e.Salary },
+				func(a, b float64) float64 { return a + b },
+			),
+		)
+
+		assert.Equal(t, map[string]float64{"eng": 420, "ops": 90}, got)
+	})
+
+	t.Run("last value wins without merge", func(t *testing.T) {
+		got := seq.CollectWith(seq.Yield(employees...),
+			seq.ToMapCollector(
+				func(e employee) string { return e.Department },
+				func(e employee) string { return e.Name },
+				nil,
+			),
+		)
+
+		assert.Equal(t, map[string]string{"eng": "cid", "ops": "dee"}, got)
+	})
+}
+
+func Test_NewCollector(t *testing.T) {
+	upper := seq.NewCollector(
+		func() *strings.Builder { return &strings.Builder{} },
+		func(b *strings.Builder, s string) *strings.Builder {
+			b.WriteString(strings.ToUpper(s))
+			return b
+		},
+		(*strings.Builder).String,
+	)
+
+	assert.Equal(t, "ABC", seq.CollectWith(seq.Yield("a", "b", "c"), upper))
+}
@@ -0,0 +1,381 @@
+package seq
+
+import (
+	"iter"
+	"slices"
+)
+
+// Permutations returns a sequence of all ordered arrangements of k values from a slice.
+//
+// The permutations are yielded in lexicographic order of the value indexes, so the order
+// is reproducible. Values are treated as distinct based on their position, not their value.
+// Each permutation is a new slice. Use [PermutationsInPlace] to avoid the allocations.
+// This panics if k is negative.
+//
+// Example:
+//
+//	// yields ([a b]), ([a c]), ([b a]), ([b c]), ([c a]), ([c b])
+//	perms := seq.Permutations([]string{"a", "b", "c"}, 2)
+func Permutations[V any](vals []V, k int) iter.Seq[[]V] {
+	if k < 0 {
+		panic("seq.Permutations: k must be non-negative")
+	}
+
+	return pickIndexes(vals, permutationIndexes(len(vals), k), false)
+}
+
+// PermutationsInPlace is like [Permutations], but yields the same slice for every permutation,
+// overwriting it in place.
+//
+// The yielded slice must not be retained or modified; use [slices.Clone] to keep a copy.
+// This panics if k is negative.
+func PermutationsInPlace[V any](vals []V, k int) iter.Seq[[]V] {
+	if k < 0 {
+		panic("seq.PermutationsInPlace: k must be non-negative")
+	}
+
+	return pickIndexes(vals, permutationIndexes(len(vals), k), true)
+}
+
+// Combinations returns a sequence of all unordered selections of k values from a slice.
+//
+// The combinations are yielded in lexicographic order of the value indexes, and the values
+// of each combination keep the order of the slice.
+// Values are treated as distinct based on their position, not their value.
+// Each combination is a new slice. Use [CombinationsInPlace] to avoid the allocations.
+// This panics if k is negative.
+//
+// Example:
+//
+//	// yields ([a b]), ([a c]), ([b c])
+//	combs := seq.Combinations([]string{"a", "b", "c"}, 2)
+func Combinations[V any](vals []V, k int) iter.Seq[[]V] {
+	if k < 0 {
+		panic("seq.Combinations: k must be non-negative")
+	}
+
+	return pickIndexes(vals, combinationIndexes(len(vals), k), false)
+}
+
+// CombinationsInPlace is like [Combinations], but yields the same slice for every combination,
+// overwriting it in place.
+//
+// The yielded slice must not be retained or modified; use [slices.Clone] to keep a copy.
+// This panics if k is negative.
+func CombinationsInPlace[V any](vals []V, k int) iter.Seq[[]V] {
+	if k < 0 {
+		panic("seq.CombinationsInPlace: k must be non-negative")
+	}
+
+	return pickIndexes(vals, combinationIndexes(len(vals), k), true)
+}
+
+// CombinationsWithReplacement returns a sequence of all unordered selections of k values
+// from a slice where each value may be selected more than once.
+//
+// The combinations are yielded in lexicographic order of the value indexes.
+// Each combination is a new slice. Use [CombinationsWithReplacementInPlace] to avoid
+// the allocations.
+// This panics if k is negative.
+//
+// Example:
+//
+//	// yields ([a a]), ([a b]), ([b b])
+//	combs := seq.CombinationsWithReplacement([]string{"a", "b"}, 2)
+func CombinationsWithReplacement[V any](vals []V, k int) iter.Seq[[]V] {
+	if k < 0 {
+		panic("seq.CombinationsWithReplacement: k must be non-negative")
+	}
+
+	return pickIndexes(vals, combinationWithReplacementIndexes(len(vals), k), false)
+}
+
+// CombinationsWithReplacementInPlace is like [CombinationsWithReplacement], but yields the same
+// slice for every combination, overwriting it in place.
+//
+// The yielded slice must not be retained or modified; use [slices.Clone] to keep a copy.
+// This panics if k is negative.
+func CombinationsWithReplacementInPlace[V any](vals []V, k int) iter.Seq[[]V] {
+	if k < 0 {
+		panic("seq.CombinationsWithReplacementInPlace: k must be non-negative")
+	}
+
+	return pickIndexes(vals, combinationWithReplacementIndexes(len(vals), k), true)
+}
+
+// PowerSet returns a sequence of all subsets of the values of a slice.
+//
+// The subsets are yielded by increasing size, starting with the empty subset, and
+// subsets of the same size are yielded in the same order as [Combinations].
+// Each subset is a new slice. Use [PowerSetInPlace] to avoid the allocations.
+//
+// Example:
+//
+//	// yields ([]), ([a]), ([b]), ([a b])
+//	subsets := seq.PowerSet([]string{"a", "b"})
+func PowerSet[V any](vals []V) iter.Seq[[]V] {
+	return pickIndexes(vals, powerSetIndexes(len(vals)), false)
+}
+
+// PowerSetInPlace is like [PowerSet], but yields the same slice for every subset,
+// overwriting it in place.
+//
+// The yielded slice must not be retained or modified; use [slices.Clone] to keep a copy.
+func PowerSetInPlace[V any](vals []V) iter.Seq[[]V] {
+	return pickIndexes(vals, powerSetIndexes(len(vals)), true)
+}
+
+// CartesianProduct returns a sequence of all combinations that take one value from each
+// of the given sequences.
+//
+// The combinations are yielded in lexicographic order of the value indexes, so the last
+// sequence varies fastest. Each given sequence is iterated only once.
+// Each combination is a new slice. Use [CartesianProductInPlace] to avoid the allocations.
+//
+// Example:
+//
+//	// yields ([1 a]), ([1 b]), ([2 a]), ([2 b])
+//	pairs := seq.CartesianProduct(seq.Yield("1", "2"), seq.Yield("a", "b"))
+func CartesianProduct[V any](seqs ...iter.Seq[V]) iter.Seq[[]V] {
+	return cartesianProduct(seqs, false)
+}
+
+// CartesianProductInPlace is like [CartesianProduct], but yields the same slice for every
+// combination, overwriting it in place.
+//
+// The yielded slice must not be retained or modified; use [slices.Clone] to keep a copy.
+func CartesianProductInPlace[V any](seqs ...iter.Seq[V]) iter.Seq[[]V] {
+	return cartesianProduct(seqs, true)
+}
+
+// cartesianProduct returns the Cartesian product of sequences, optionally reusing the
+// yielded slice.
+func cartesianProduct[V any](seqs []iter.Seq[V], inPlace bool) iter.Seq[[]V] {
+	return func(yield func([]V) bool) {
+		pools := make([][]V, len(seqs))
+		sizes := make([]int, len(seqs))
+
+		for i, s := range seqs {
+			pools[i] = slices.Collect(s)
+			sizes[i] = len(pools[i])
+		}
+
+		var buf []V
+		for indexes := range productIndexes(sizes) {
+			if buf == nil || !inPlace {
+				buf = make([]V, len(indexes))
+			}
+
+			for i, j := range indexes {
+				buf[i] = pools[i][j]
+			}
+
+			if !yield(buf) {
+				return
+			}
+		}
+	}
+}
+
+// pickIndexes returns a sequence of slices of values selected by a sequence of indexes,
+// optionally reusing the yielded slice.
+func pickIndexes[V any](vals []V, indexes iter.Seq[[]int], inPlace bool) iter.Seq[[]V] {
+	return func(yield func([]V) bool) {
+		var buf []V
+
+		for idx := range indexes {
+			if buf == nil || !inPlace {
+				buf = make([]V, 0, len(idx))
+			}
+
+			buf = buf[:0]
+			for _, i := range idx {
+				buf = append(buf, vals[i])
+			}
+
+			if !yield(buf) {
+				return
+			}
+		}
+	}
+}
+
+// permutationIndexes yields the indexes of the k-permutations of n values in lexicographic order.
+//
+// The yielded slice is reused.
+func permutationIndexes(n, k int) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		if k > n {
+			return
+		}
+
+		indexes := make([]int, n)
+		for i := range indexes {
+			indexes[i] = i
+		}
+
+		// cycles[i] counts the remaining choices for position i
+		cycles := make([]int, k)
+		for i := range cycles {
+			cycles[i] = n - i
+		}
+
+		if !yield(indexes[:k]) {
+			return
+		}
+
+		for {
+			i := k - 1
+			for ; i >= 0; i-- {
+				cycles[i]--
+
+				if cycles[i] == 0 {
+					// rotate position i to the end and reset its choices
+					moved := indexes[i]
+					copy(indexes[i:], indexes[i+1:])
+					indexes[n-1] = moved
+					cycles[i] = n - i
+
+					continue
+				}
+
+				j := n - cycles[i]
+				indexes[i], indexes[j] = indexes[j], indexes[i]
+
+				if !yield(indexes[:k]) {
+					return
+				}
+
+				break
+			}
+
+			if i < 0 {
+				return
+			}
+		}
+	}
+}
+
+// combinationIndexes yields the indexes of the k-combinations of n values in lexicographic order.
+//
+// The yielded slice is reused.
+func combinationIndexes(n, k int) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		if k > n {
+			return
+		}
+
+		indexes := make([]int, k)
+		for i := range indexes {
+			indexes[i] = i
+		}
+
+		for {
+			if !yield(indexes) {
+				return
+			}
+
+			// find the rightmost index that can be incremented
+			i := k - 1
+			for i >= 0 && indexes[i] == i+n-k {
+				i--
+			}
+
+			if i < 0 {
+				return
+			}
+
+			indexes[i]++
+			for j := i + 1; j < k; j++ {
+				indexes[j] = indexes[j-1] + 1
+			}
+		}
+	}
+}
+
+// combinationWithReplacementIndexes yields the indexes of the k-combinations with replacement of
+// n values in lexicographic order.
+//
+// The yielded slice is reused.
+func combinationWithReplacementIndexes(n, k int) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		if n == 0 && k > 0 {
+			return
+		}
+
+		indexes := make([]int, k)
+
+		for {
+			if !yield(indexes) {
+				return
+			}
+
+			// find the rightmost index that can be incremented
+			i := k - 1
+			for i >= 0 && indexes[i] == n-1 {
+				i--
+			}
+
+			if i < 0 {
+				return
+			}
+
+			next := indexes[i] + 1
+			for j := i; j < k; j++ {
+				indexes[j] = next
+			}
+		}
+	}
+}
+
+// powerSetIndexes yields the indexes of all subsets of n values by increasing size.
+//
+// The yielded slice is reused.
+func powerSetIndexes(n int) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		for k := 0; k <= n; k++ {
+			for indexes := range combinationIndexes(n, k) {
+				if !yield(indexes) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// productIndexes yields the indexes of the Cartesian product of pools with the given sizes
+// in lexicographic order.
+//
+// The yielded slice is reused.
+func productIndexes(sizes []int) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		for _, size := range sizes {
+			if size == 0 {
+				return
+			}
+		}
+
+		indexes := make([]int, len(sizes))
+
+		for {
+			if !yield(indexes) {
+				return
+			}
+
+			// advance like an odometer, with the last position varying fastest
+			i := len(sizes) - 1
+			for ; i >= 0; i-- {
+				indexes[i]++
+				if indexes[i] < sizes[i] {
+					break
+				}
+
+				indexes[i] = 0
+			}
+
+			if i < 0 {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package seq_test
+
+import (
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/arielsrv/go-seq"
+	"github.com/arielsrv/go-seq/internal/seqtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Permutations(t *testing.T) {
+	tests := []struct {
+		name string
+		vals []int
+		k    int
+		want [][]int
+	}{
+		{
+			name: "all",
+			vals: []int{1, 2, 3},
+			k:    3,
+			want: [][]int{{1, 2, 3}, {1, 3, 2}, {2, 1, 3}, {2, 3, 1}, {3, 1, 2}, {3, 2, 1}},
+		},
+		{
+			name: "partial",
+			vals: []int{1, 2, 3},
+			k:    2,
+			want: [][]int{{1, 2}, {1, 3}, {2, 1}, {2, 3}, {3, 1}, {3, 2}},
+		},
+		{
+			name: "zero",
+			vals: []int{1, 2},
+			k:    0,
+			want: [][]int{{}},
+		},
+		{
+			name: "k greater than length",
+			vals: []int{1, 2},
+			k:    3,
+			want: nil,
+		},
+		{
+			name: "empty",
+			vals: nil,
+			k:    1,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seqtest.AssertEqual(t, tt.want, seq.Permutations(tt.vals, tt.k))
+			seqtest.AssertEqual(t, tt.want, seq.Select(seq.PermutationsInPlace(tt.vals, tt.k), slices.Clone))
+		})
+	}
+
+	t.Run("count", func(t *testing.T) {
+		assert.Equal(t, 5*4*3, seq.Count(seq.PermutationsInPlace([]int{1, 2, 3, 4, 5}, 3)))
+	})
+
+	assert.Panics(t, func() { seq.Permutations([]int{1}, -1) })
+	assert.Panics(t, func() { seq.PermutationsInPlace([]int{1}, -1) })
+}
+
+func Test_Combinations(t *testing.T) {
+	tests := []struct {
+		name string
+		vals []string
+		k    int
+		want [][]string
+	}{
+		{
+			name: "pairs",
+			vals: []string{"a", "b", "c", "d"},
+			k:    2,
+			want: [][]string{{"a", "b"}, {"a", "c"}, {"a", "d"}, {"b", "c"}, {"b", "d"}, {"c", "d"}},
+		},
+		{
+			name: "all",
+			vals: []string{"a", "b", "c"},
+			k:    3,
+			want: [][]string{{"a", "b", "c"}},
+		},
+		{
+			name: "zero",
+			vals: []string{"a"},
+			k:    0,
+			want: [][]string{{}},
+		},
+		{
+			name: "k greater than length",
+			vals: []string{"a"},
+			k:    2,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seqtest.AssertEqual(t, tt.want, seq.Combinations(tt.vals, tt.k))
+			seqtest.AssertEqual(t, tt.want, seq.Select(seq.CombinationsInPlace(tt.vals, tt.k), slices.Clone))
+		})
+	}
+
+	assert.Panics(t, func() { seq.Combinations([]int{1}, -1) })
+	assert.Panics(t, func() { seq.CombinationsInPlace([]int{1}, -1) })
+}
+
+func Test_CombinationsWithReplacement(t *testing.T) {
+	tests := []struct {
+		name string
+		vals []string
+		k    int
+		want [][]string
+	}{
+		{
+			name: "pairs",
+			vals: []string{"a", "b", "c"},
+			k:    2,
+			want: [][]string{{"a", "a"}, {"a", "b"}, {"a", "c"}, {"b", "b"}, {"b", "c"}, {"c", "c"}},
+		},
+		{
+			name: "k greater than length",
+			vals: []string{"a"},
+			k:    3,
+			want: [][]string{{"a", "a", "a"}},
+		},
+		{
+			name: "zero",
+			vals: nil,
+			k:    0,
+			want: [][]string{{}},
+		},
+		{
+			name: "empty",
+			vals: nil,
+			k:    2,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seqtest.AssertEqual(t, tt.want, seq.CombinationsWithReplacement(tt.vals, tt.k))
+			inPlace := seq.CombinationsWithReplacementInPlace(tt.vals, tt.k)
+			seqtest.AssertEqual(t, tt.want, seq.Select(inPlace, slices.Clone))
+		})
+	}
+
+	assert.Panics(t, func() { seq.CombinationsWithReplacement([]int{1}, -1) })
+	assert.Panics(t, func() { seq.CombinationsWithReplacementInPlace([]int{1}, -1) })
+}
+
+func Test_PowerSet(t *testing.T) {
+	tests := []struct {
+		name string
+		vals []int
+		want [][]int
+	}{
+		{
+			name: "three values",
+			vals: []int{1, 2, 3},
+			want: [][]int{{}, {1}, {2}, {3}, {1, 2}, {1, 3}, {2, 3}, {1, 2, 3}},
+		},
+		{
+			name: "empty",
+			vals: nil,
+			want: [][]int{{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seqtest.AssertEqual(t, tt.want, seq.PowerSet(tt.vals))
+			seqtest.AssertEqual(t, tt.want, seq.Select(seq.PowerSetInPlace(tt.vals), slices.Clone))
+		})
+	}
+}
+
+func Test_CartesianProduct(t *testing.T) {
+	tests := []struct {
+		name string
+		seqs []iter.Seq[string]
+		want [][]string
+	}{
+		{
+			name: "two sequences",
+			seqs: []iter.Seq[string]{seq.Yield("1", "2"), seq.Yield("a", "b", "c")},
+			want: [][]string{{"1", "a"}, {"1", "b"}, {"1", "c"}, {"2", "a"}, {"2", "b"}, {"2", "c"}},
+		},
+		{
+			name: "three sequences",
+			seqs: []iter.Seq[string]{seq.Yield("x"), seq.Yield("1", "2"), seq.Yield("a")},
+			want: [][]string{{"x", "1", "a"}, {"x", "2", "a"}},
+		},
+		{
+			name: "one empty sequence",
+			seqs: []iter.Seq[string]{seq.Yield("1", "2"), seq.Empty[string]()},
+			want: nil,
+		},
+		{
+			name: "no sequences",
+			seqs: nil,
+			want: [][]string{{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seqtest.AssertEqual(t, tt.want, seq.CartesianProduct(tt.seqs...))
+			seqtest.AssertEqual(t, tt.want, seq.Select(seq.CartesianProductInPlace(tt.seqs...), slices.Clone))
+		})
+	}
+}
+
+func Test_CartesianProductInPlace_ReusesSlice(t *testing.T) {
+	var first []int
+
+	for combination := range seq.CartesianProductInPlace(seq.Yield(1, 2), seq.Yield(3, 4)) {
+		if first == nil {
+			first = combination
+			continue
+		}
+
+		assert.Same(t, &first[0], &combination[0])
+	}
+}
+
+func Test_Combinatorics_EarlyReturn(t *testing.T) {
+	vals := []int{1, 2, 3, 4}
+
+	assert.Len(t, limitedCollector(seq.Permutations(vals, 2), 3), 3)
+	assert.Len(t, limitedCollector(seq.Combinations(vals, 2), 3), 3)
+	assert.Len(t, limitedCollector(seq.CombinationsWithReplacement(vals, 2), 3), 3)
+	assert.Len(t, limitedCollector(seq.PowerSet(vals), 3), 3)
+	assert.Len(t, limitedCollector(seq.CartesianProduct(seq.Yield(vals...), seq.Yield(vals...)), 3), 3)
+}
@@ -0,0 +1,220 @@
+package seq
+
+import (
+	"cmp"
+	"container/heap"
+	"errors"
+	"iter"
+	"math"
+	"slices"
+)
+
+// CountMinSketch estimates the frequency of values in a stream using a fixed amount of memory.
+//
+// Estimates are never lower than the true count. With probability 1-delta, an estimate exceeds
+// the true count by at most eps times the total count of all values.
+type CountMinSketch[K any] struct {
+	hash     func(K) uint64
+	counters [][]uint64
+	width    uint64
+	total    uint64
+}
+
+// NewCountMinSketch creates a Count-Min Sketch with error factor eps and failure probability delta.
+//
+// This panics if eps or delta are not between 0 and 1 (exclusive).
+func NewCountMinSketch[K comparable](eps, delta float64) *CountMinSketch[K] {
+	return NewCountMinSketchFunc(eps, delta, hashComparable[K])
+}
+
+// NewCountMinSketchFunc creates a Count-Min Sketch with error factor eps and failure probability
+// delta using a function to hash values.
+//
+// Sketches can only be merged if they use the same hash function.
+// This panics if eps or delta are not between 0 and 1 (exclusive).
+func NewCountMinSketchFunc[K any](eps, delta float64, hash func(K) uint64) *CountMinSketch[K] {
+	if !(eps > 0 && eps < 1) {
+		panic("seq.NewCountMinSketch: eps must be between 0 and 1")
+	}
+
+	if !(delta > 0 && delta < 1) {
+		panic("seq.NewCountMinSketch: delta must be between 0 and 1")
+	}
+
+	width := uint64(math.Ceil(math.E / eps))
+	depth := int(math.Ceil(math.Log(1 / delta)))
+
+	counters := make([][]uint64, depth)
+	for i := range counters {
+		counters[i] = make([]uint64, width)
+	}
+
+	return &CountMinSketch[K]{
+		hash:     hash,
+		counters: counters,
+		width:    width,
+	}
+}
+
+// Add increments the count of a value by n.
+func (s *CountMinSketch[K]) Add(k K, n uint64) {
+	h1, h2 := s.hashes(k)
+
+	for i, row := range s.counters {
+		row[(h1+uint64(i)*h2)%s.width] += n
+	}
+
+	s.total += n
+}
+
+// Count returns the estimated count of a value.
+func (s *CountMinSketch[K]) Count(k K) uint64 {
+	h1, h2 := s.hashes(k)
+	count := uint64(math.MaxUint64)
+
+	for i, row := range s.counters {
+		count = min(count, row[(h1+uint64(i)*h2)%s.width])
+	}
+
+	return count
+}
+
+// Total returns the total count of all values added to the sketch.
+func (s *CountMinSketch[K]) Total() uint64 {
+	return s.total
+}
+
+// Merge adds the counts of another sketch to this sketch.
+//
+// Both sketches must have been created with the same eps, delta and hash function.
+func (s *CountMinSketch[K]) Merge(other *CountMinSketch[K]) error {
+	if s.width != other.width || len(s.counters) != len(other.counters) {
+		return errors.New("seq.CountMinSketch: cannot merge sketches with different sizes")
+	}
+
+	for i, row := range other.counters {
+		for j, c := range row {
+			s.counters[i][j] += c
+		}
+	}
+
+	s.total += other.total
+
+	return nil
+}
+
+// hashes returns the two hashes used to derive the counter index in each row.
+func (s *CountMinSketch[K]) hashes(k K) (uint64, uint64) {
+	h1 := mix64(s.hash(k))
+	h2 := mix64(h1) | 1
+
+	return h1, h2
+}
+
+// HeavyHitter is an approximately frequent value reported by [HeavyHitters].
+type HeavyHitter[K any] struct {
+	// Key is the value.
+	Key K
+
+	// Count is the estimated number of occurrences. It is never lower than the true count.
+	Count int
+
+	// Error is the maximum amount by which Count may exceed the true count.
+	Error int
+}
+
+// HeavyHitters returns the approximate k most frequent values in a sequence using a bounded amount
+// of memory, ordered by descending count.
+//
+// This uses the Space-Saving algorithm with up to max(k, 1/eps) counters, which are allocated as
+// distinct values are read. Every value that occurs more than eps times the length of the
+// sequence is guaranteed to be tracked, and each count overestimates the true count by at most
+// eps times the length of the sequence.
+// Use [CountGrouped] instead if exact counts are required.
+// This panics if k is negative or eps is not between 0 and 1 (exclusive).
+func HeavyHitters[K comparable](seq iter.Seq[K], k int, eps float64) []HeavyHitter[K] {
+	if k < 0 {
+		panic("seq.HeavyHitters: k must be non-negative")
+	}
+
+	if !(eps > 0 && eps < 1) {
+		panic("seq.HeavyHitters: eps must be between 0 and 1")
+	}
+
+	capacity := max(k, int(min(math.Ceil(1/eps), math.MaxInt32)))
+
+	counters := make(map[K]*spaceSavingCounter[K])
+	var minHeap spaceSavingHeap[K]
+
+	for v := range seq {
+		if c, ok := counters[v]; ok {
+			c.count++
+			heap.Fix(&minHeap, c.index)
+
+			continue
+		}
+
+		if len(minHeap) < capacity {
+			c := &spaceSavingCounter[K]{key: v, count: 1}
+			counters[v] = c
+			heap.Push(&minHeap, c)
+
+			continue
+		}
+
+		// replace the least frequent value, which may have been this value all along
+		c := minHeap[0]
+		delete(counters, c.key)
+
+		c.key = v
+		c.err = c.count
+		c.count++
+		counters[v] = c
+		heap.Fix(&minHeap, 0)
+	}
+
+	slices.SortStableFunc(minHeap, func(a, b *spaceSavingCounter[K]) int {
+		return cmp.Compare(b.count, a.count)
+	})
+
+	hitters := make([]HeavyHitter[K], 0, min(k, len(minHeap)))
+	for _, c := range minHeap[:min(k, len(minHeap))] {
+		hitters = append(hitters, HeavyHitter[K]{Key: c.key, Count: c.count, Error: c.err})
+	}
+
+	return hitters
+}
+
+// spaceSavingCounter is a monitored value of the Space-Saving algorithm.
+type spaceSavingCounter[K any] struct {
+	key   K
+	count int
+	err   int
+	index int
+}
+
+// spaceSavingHeap is a min-heap of counters ordered by count.
+type spaceSavingHeap[K any] []*spaceSavingCounter[K]
+
+func (h spaceSavingHeap[K]) Len() int           { return len(h) }
+func (h spaceSavingHeap[K]) Less(i, j int) bool { return h[i].count < h[j].count }
+
+func (h spaceSavingHeap[K]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *spaceSavingHeap[K]) Push(x any) {
+	c := x.(*spaceSavingCounter[K])
+	c.index = len(*h)
+	*h = append(*h, c)
+}
+
+func (h *spaceSavingHeap[K]) Pop() any {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+
+	return last
+}
@@ -0,0 +1,111 @@
+package seq_test
+
+import (
+	"testing"
+
+	"github.com/arielsrv/go-seq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// zipfKeys returns a skewed sequence where key i (from 1 to n) occurs 1000/i times.
+func zipfKeys(n int) []int {
+	var keys []int
+	for i := 1; i <= n; i++ {
+		for range 1000 / i {
+			keys = append(keys, i)
+		}
+	}
+
+	return seq.Shuffled(seq.Yield(keys...), newRand(11))
+}
+
+func Test_CountMinSketch(t *testing.T) {
+	keys := zipfKeys(200)
+	exact := seq.CountGrouped(seq.SelectKeys(seq.Yield(keys...), func(k int) int { return k }))
+
+	// the error bound only holds with probability 1-delta per key, so use a fixed hash
+	sketch := seq.NewCountMinSketchFunc(0.001, 0.01, hashInt)
+	for _, k := range keys {
+		sketch.Add(k, 1)
+	}
+
+	assert.Equal(t, uint64(len(keys)), sketch.Total())
+
+	maxErr := uint64(0.001 * float64(len(keys)))
+	for k, count := range exact {
+		got := sketch.Count(k)
+		assert.GreaterOrEqual(t, got, uint64(count))
+		assert.LessOrEqual(t, got, uint64(count)+maxErr)
+	}
+
+	assert.LessOrEqual(t, sketch.Count(-1), maxErr)
+}
+
+func Test_CountMinSketch_Merge(t *testing.T) {
+	a := seq.NewCountMinSketch[string](0.01, 0.01)
+	b := seq.NewCountMinSketch[string](0.01, 0.01)
+
+	a.Add("x", 3)
+	b.Add("x", 4)
+	b.Add("y", 1)
+
+	require.NoError(t, a.Merge(b))
+	assert.GreaterOrEqual(t, a.Count("x"), uint64(7))
+	assert.GreaterOrEqual(t, a.Count("y"), uint64(1))
+	assert.Equal(t, uint64(8), a.Total())
+
+	assert.Error(t, a.Merge(seq.NewCountMinSketch[string](0.1, 0.01)))
+}
+
+func Test_NewCountMinSketch_Panics(t *testing.T) {
+	assert.Panics(t, func() { seq.NewCountMinSketch[int](0, 0.01) })
+	assert.Panics(t, func() { seq.NewCountMinSketch[int](0.01, 1) })
+}
+
+func Test_HeavyHitters(t *testing.T) {
+	t.Run("skewed distribution", func(t *testing.T) {
+		got := seq.HeavyHitters(seq.Yield(zipfKeys(1000)...), 3, 0.01)
+
+		require.Len(t, got, 3)
+		assert.Equal(t, []int{1, 2, 3}, []int{got[0].Key, got[1].Key, got[2].Key})
+
+		for _, h := range got {
+			trueCount := 1000 / h.Key
+			assert.GreaterOrEqual(t, h.Count, trueCount)
+			assert.LessOrEqual(t, h.Count-h.Error, trueCount)
+		}
+	})
+
+	t.Run("exact when capacity is not exceeded", func(t *testing.T) {
+		got := seq.HeavyHitters(seq.Yield("a", "b", "a", "c", "a", "b"), 2, 0.1)
+
+		assert.Equal(t, []seq.HeavyHitter[string]{
+			{Key: "a", Count: 3, Error: 0},
+			{Key: "b", Count: 2, Error: 0},
+		}, got)
+	})
+
+	t.Run("fewer values than k", func(t *testing.T) {
+		got := seq.HeavyHitters(seq.Yield("a"), 5, 0.1)
+		assert.Equal(t, []seq.HeavyHitter[string]{{Key: "a", Count: 1}}, got)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		assert.Empty(t, seq.HeavyHitters(seq.Empty[string](), 5, 0.1))
+	})
+
+	t.Run("small eps", func(t *testing.T) {
+		allocs := testing.AllocsPerRun(10, func() {
+			seq.HeavyHitters(seq.Yield("a", "b", "a"), 1, 1e-9)
+		})
+
+		// counters are allocated for the values read, not for the capacity
+		assert.Less(t, allocs, float64(20))
+		assert.Equal(t, []seq.HeavyHitter[string]{{Key: "a", Count: 2}},
+			seq.HeavyHitters(seq.Yield("a", "b", "a"), 1, 1e-9))
+	})
+
+	assert.Panics(t, func() { seq.HeavyHitters(seq.Yield(1), -1, 0.1) })
+	assert.Panics(t, func() { seq.HeavyHitters(seq.Yield(1), 1, 0) })
+}
@@ -0,0 +1,185 @@
+package seq
+
+import (
+	"iter"
+	"slices"
+	"sync/atomic"
+)
+
+// Cursor reads values from a sequence one at a time, with support for looking ahead
+// and pushing values back.
+//
+// A cursor holds resources until it is closed, so [Cursor.Close] must always be called,
+// typically with defer. A cursor is not safe for concurrent use.
+//
+// Example:
+//
+//	c := seq.NewCursor(tokens)
+//	defer c.Close()
+//
+//	for tok, ok := c.Next(); ok; tok, ok = c.Next() {
+//		if next, _ := c.Peek(); tok == "-" && next == "-" {
+//			// handle "--"
+//		}
+//	}
+type Cursor[V any] struct {
+	next func() (V, bool)
+	stop func()
+
+	// buf holds values that were read ahead or pushed back, next value first
+	buf []V
+
+	// tracker counts the cursor until it is closed, if not nil
+	tracker *CursorTracker
+
+	done   bool
+	closed bool
+}
+
+// NewCursor creates a cursor over a sequence.
+func NewCursor[V any](seq iter.Seq[V]) *Cursor[V] {
+	next, stop := iter.Pull(seq)
+
+	return &Cursor[V]{next: next, stop: stop}
+}
+
+// NewTrackedCursor creates a cursor over a sequence that is counted by a tracker until it is
+// closed.
+func NewTrackedCursor[V any](seq iter.Seq[V], tracker *CursorTracker) *Cursor[V] {
+	c := NewCursor(seq)
+	c.tracker = tracker
+	tracker.open.Add(1)
+
+	return c
+}
+
+// CursorTracker counts the cursors created with [NewTrackedCursor] that are not yet closed.
+//
+// This is intended for tests to detect leaked cursors. Each test can use its own tracker,
+// so tests that run in parallel do not affect each other. A tracker is safe for concurrent use.
+type CursorTracker struct {
+	open atomic.Int64
+}
+
+// Open returns the number of tracked cursors that were created but not yet closed.
+func (tr *CursorTracker) Open() int {
+	return int(tr.open.Load())
+}
+
+// Next returns the next value and advances the cursor.
+//
+// A second return value indicates whether there was a value.
+func (c *Cursor[V]) Next() (V, bool) {
+	if len(c.buf) > 0 {
+		v := c.buf[0]
+		c.buf = c.buf[1:]
+
+		return v, true
+	}
+
+	return c.pull()
+}
+
+// Peek returns the next value without advancing the cursor.
+//
+// A second return value indicates whether there was a value.
+func (c *Cursor[V]) Peek() (V, bool) {
+	if c.fill(1) == 0 {
+		var zero V
+		return zero, false
+	}
+
+	return c.buf[0], true
+}
+
+// PeekN returns up to n next values without advancing the cursor.
+//
+// Fewer than n values are returned if the sequence ends.
+// This panics if n is negative.
+func (c *Cursor[V]) PeekN(n int) []V {
+	if n < 0 {
+		panic("seq.Cursor.PeekN: n must be non-negative")
+	}
+
+	available := c.fill(n)
+
+	return append(make([]V, 0, available), c.buf[:available]...)
+}
+
+// Unread pushes a value back so that it is returned by the next call to [Cursor.Next].
+//
+// Values that are unread are returned in the reverse order in which they were unread.
+// Unread values are discarded when the cursor is closed.
+func (c *Cursor[V]) Unread(v V) {
+	if c.closed {
+		return
+	}
+
+	c.buf = slices.Insert(c.buf, 0, v)
+}
+
+// Remaining returns a sequence of the values that have not been read yet.
+//
+// Iterating the sequence advances the cursor; stopping the iteration early leaves the
+// remaining values in the cursor.
+func (c *Cursor[V]) Remaining() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for {
+			v, ok := c.Next()
+			if !ok {
+				return
+			}
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Close releases the resources held by the cursor.
+//
+// After Close, the cursor yields no more values. It is safe to call Close more than once.
+func (c *Cursor[V]) Close() {
+	if c.closed {
+		return
+	}
+
+	c.closed = true
+	c.buf = nil
+	c.stop()
+
+	if c.tracker != nil {
+		c.tracker.open.Add(-1)
+	}
+}
+
+// fill reads ahead until at least n values are buffered or the sequence ends,
+// and returns the number of buffered values available, up to n.
+func (c *Cursor[V]) fill(n int) int {
+	for len(c.buf) < n {
+		v, ok := c.pull()
+		if !ok {
+			break
+		}
+
+		c.buf = append(c.buf, v)
+	}
+
+	return min(n, len(c.buf))
+}
+
+// pull reads the next value from the sequence.
+func (c *Cursor[V]) pull() (V, bool) {
+	if c.done || c.closed {
+		var zero V
+		return zero, false
+	}
+
+	v, ok := c.next()
+	if !ok {
+		c.done = true
+	}
+
+	return v, ok
+}
@@ -0,0 +1,166 @@
+package seq_test
+
+import (
+	"testing"
+
+	"github.com/arielsrv/go-seq"
+	"github.com/arielsrv/go-seq/internal/seqtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Cursor_Next(t *testing.T) {
+	tracker := seqtest.NewCursorTracker(t)
+
+	c := seq.NewTrackedCursor(seq.Yield(1, 2), tracker)
+	defer c.Close()
+
+	v, ok := c.Next()
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	v, ok = c.Next()
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+
+	_, ok = c.Next()
+	assert.False(t, ok)
+
+	_, ok = c.Next()
+	assert.False(t, ok, "exhausted cursor stays exhausted")
+}
+
+func Test_Cursor_Peek(t *testing.T) {
+	tracker := seqtest.NewCursorTracker(t)
+
+	c := seq.NewTrackedCursor(seq.Yield("a", "b"), tracker)
+	defer c.Close()
+
+	v, ok := c.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, "a", v)
+
+	v, _ = c.Peek()
+	assert.Equal(t, "a", v, "peek does not advance")
+
+	v, _ = c.Next()
+	assert.Equal(t, "a", v)
+
+	c.Next()
+	_, ok = c.Peek()
+	assert.False(t, ok)
+}
+
+func Test_Cursor_PeekN(t *testing.T) {
+	tests := []struct {
+		name string
+		vals []int
+		n    int
+		want []int
+	}{
+		{name: "some", vals: []int{1, 2, 3, 4}, n: 2, want: []int{1, 2}},
+		{name: "more than available", vals: []int{1, 2}, n: 5, want: []int{1, 2}},
+		{name: "zero", vals: []int{1, 2}, n: 0, want: []int{}},
+		{name: "empty", vals: nil, n: 2, want: []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := seqtest.NewCursorTracker(t)
+
+			c := seq.NewTrackedCursor(seq.Yield(tt.vals...), tracker)
+			defer c.Close()
+
+			assert.Equal(t, tt.want, c.PeekN(tt.n))
+			seqtest.AssertEqual(t, tt.vals, c.Remaining())
+		})
+	}
+
+	t.Run("negative", func(t *testing.T) {
+		c := seq.NewCursor(seq.Yield(1))
+		defer c.Close()
+
+		assert.Panics(t, func() { c.PeekN(-1) })
+	})
+}
+
+func Test_Cursor_Unread(t *testing.T) {
+	tracker := seqtest.NewCursorTracker(t)
+
+	c := seq.NewTrackedCursor(seq.Yield(1, 2, 3), tracker)
+	defer c.Close()
+
+	first, _ := c.Next()
+	second, _ := c.Next()
+
+	c.Unread(second)
+	c.Unread(first)
+	c.Unread(0)
+
+	seqtest.AssertEqual(t, []int{0, 1, 2, 3}, c.Remaining())
+
+	// values can be unread after the sequence is exhausted
+	c.Unread(9)
+	v, ok := c.Next()
+	assert.True(t, ok)
+	assert.Equal(t, 9, v)
+}
+
+func Test_Cursor_Remaining(t *testing.T) {
+	tracker := seqtest.NewCursorTracker(t)
+
+	c := seq.NewTrackedCursor(seq.Yield(1, 2, 3, 4, 5), tracker)
+	defer c.Close()
+
+	c.Next()
+	assert.Equal(t, []int{2, 3}, limitedCollector(c.Remaining(), 2))
+	seqtest.AssertEqual(t, []int{4, 5}, c.Remaining())
+	seqtest.AssertEqual(t, nil, c.Remaining())
+}
+
+func Test_Cursor_Close(t *testing.T) {
+	var tracker seq.CursorTracker
+
+	stopped := false
+	source := func(yield func(int) bool) {
+		defer func() { stopped = true }()
+
+		for i := range 10 {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	c := seq.NewTrackedCursor(source, &tracker)
+	c.Next()
+	c.Unread(42)
+	assert.Equal(t, 1, tracker.Open())
+
+	c.Close()
+	assert.True(t, stopped, "closing the cursor stops the sequence")
+	assert.Equal(t, 0, tracker.Open())
+
+	_, ok := c.Next()
+	assert.False(t, ok)
+
+	c.Unread(1)
+	_, ok = c.Peek()
+	assert.False(t, ok)
+
+	assert.NotPanics(t, c.Close)
+	assert.Equal(t, 0, tracker.Open())
+}
+
+func Test_CursorTracker(t *testing.T) {
+	var tracker seq.CursorTracker
+
+	c := seq.NewTrackedCursor(seq.Yield(1), &tracker)
+	assert.Equal(t, 1, tracker.Open(), "unclosed cursor is reported")
+
+	// untracked cursors are not counted
+	untracked := seq.NewCursor(seq.Yield(1))
+	defer untracked.Close()
+
+	c.Close()
+	assert.Equal(t, 0, tracker.Open())
+}
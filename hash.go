@@ -0,0 +1,30 @@
+package seq
+
+import (
+	"hash/maphash"
+)
+
+// hashSeed is the seed used to hash comparable values in this process.
+//
+// Sketches built with the default hash function can be merged with each other, but their
+// hashes are not stable across processes.
+var hashSeed = maphash.MakeSeed()
+
+// hashComparable hashes a comparable value.
+func hashComparable[V comparable](v V) uint64 {
+	return maphash.Comparable(hashSeed, v)
+}
+
+// mix64 scrambles the bits of a hash so that poorly distributed user-supplied hash functions
+// can be used with sketches that rely on every bit of the hash.
+//
+// This is the finalizer of the SplitMix64 generator.
+func mix64(h uint64) uint64 {
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+
+	return h
+}
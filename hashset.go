@@ -0,0 +1,179 @@
+package seq
+
+import (
+	"iter"
+)
+
+// Hasher hashes and compares values, so that values that are not comparable can be used in
+// a [HashSet].
+//
+// Equal values must have equal hashes.
+type Hasher[V any] struct {
+	Hash  func(V) uint64
+	Equal func(V, V) bool
+}
+
+// HashSet is a set of values that uses a [Hasher] instead of requiring comparable values.
+type HashSet[V any] struct {
+	hasher  Hasher[V]
+	buckets map[uint64][]V
+	n       int
+}
+
+// NewHashSet creates a new hash set from values.
+func NewHashSet[V any](hasher Hasher[V], vals ...V) *HashSet[V] {
+	s := &HashSet[V]{hasher: hasher, buckets: make(map[uint64][]V, len(vals))}
+	for _, v := range vals {
+		s.Add(v)
+	}
+
+	return s
+}
+
+// CollectHashSet collects values from a sequence into a new hash set.
+func CollectHashSet[V any](seq iter.Seq[V], hasher Hasher[V]) *HashSet[V] {
+	s := NewHashSet(hasher)
+	for v := range seq {
+		s.Add(v)
+	}
+
+	return s
+}
+
+// Add adds a value to the set.
+// Returns true if the value was added, false if it was already present.
+func (s *HashSet[V]) Add(v V) bool {
+	h := s.hasher.Hash(v)
+	if s.indexIn(h, v) >= 0 {
+		return false
+	}
+
+	s.buckets[h] = append(s.buckets[h], v)
+	s.n++
+
+	return true
+}
+
+// Remove removes a value from the set.
+// Returns true if the value was removed, false if it was not present.
+func (s *HashSet[V]) Remove(v V) bool {
+	h := s.hasher.Hash(v)
+
+	i := s.indexIn(h, v)
+	if i < 0 {
+		return false
+	}
+
+	bucket := s.buckets[h]
+	if len(bucket) == 1 {
+		delete(s.buckets, h)
+	} else {
+		bucket[i] = bucket[len(bucket)-1]
+		clear(bucket[len(bucket)-1:])
+		s.buckets[h] = bucket[:len(bucket)-1]
+	}
+
+	s.n--
+
+	return true
+}
+
+// Contains determines whether a value is present in the set.
+func (s *HashSet[V]) Contains(v V) bool {
+	return s.indexIn(s.hasher.Hash(v), v) >= 0
+}
+
+// Len returns the number of values in the set.
+func (s *HashSet[V]) Len() int {
+	return s.n
+}
+
+// Values returns a sequence of values in the set.
+func (s *HashSet[V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, bucket := range s.buckets {
+			for _, v := range bucket {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// indexIn returns the index of a value in the bucket for hash h, or -1 if it is not present.
+func (s *HashSet[V]) indexIn(h uint64, v V) int {
+	for i, other := range s.buckets[h] {
+		if s.hasher.Equal(v, other) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// DistinctHash returns distinct values from a sequence using a [Hasher] to compare values.
+//
+// The first occurrence is yielded, and any subsequent occurrences are ignored.
+func DistinctHash[V any](seq iter.Seq[V], hasher Hasher[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		set := NewHashSet(hasher)
+
+		for v := range seq {
+			if set.Add(v) {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ExceptHash returns values from a sequence that are not present in a hash set.
+func ExceptHash[V any](seq iter.Seq[V], vals *HashSet[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		set := NewHashSet(vals.hasher)
+
+		for v := range seq {
+			if !vals.Contains(v) && set.Add(v) {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// IntersectHash returns values from a sequence that are present in a hash set.
+func IntersectHash[V any](seq iter.Seq[V], vals *HashSet[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		set := NewHashSet(vals.hasher)
+
+		for v := range seq {
+			if vals.Contains(v) && set.Add(v) {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// UnionHash returns the set union of multiple sequences using a [Hasher] to compare values.
+//
+// The first occurrence is yielded, and any subsequent occurrences are ignored.
+func UnionHash[V any](hasher Hasher[V], seqs ...iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		set := NewHashSet(hasher)
+
+		for _, seq := range seqs {
+			for v := range seq {
+				if set.Add(v) {
+					if !yield(v) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package seq_test
+
+import (
+	"hash/fnv"
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/arielsrv/go-seq"
+	"github.com/arielsrv/go-seq/internal/seqtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// sliceHasher hashes slices of ints by their contents.
+var sliceHasher = seq.Hasher[[]int]{
+	Hash: func(s []int) uint64 {
+		h := fnv.New64a()
+		for _, v := range s {
+			h.Write([]byte{byte(v), byte(v >> 8), byte(v >> 16), byte(v >> 24)})
+		}
+
+		return h.Sum64()
+	},
+	Equal: slices.Equal[[]int],
+}
+
+// collidingHasher hashes every value to the same bucket.
+var collidingHasher = seq.Hasher[[]int]{
+	Hash:  func([]int) uint64 { return 0 },
+	Equal: slices.Equal[[]int],
+}
+
+func Test_HashSet(t *testing.T) {
+	hashers := map[string]seq.Hasher[[]int]{
+		"distinct hashes":  sliceHasher,
+		"colliding hashes": collidingHasher,
+	}
+
+	for name, hasher := range hashers {
+		t.Run(name, func(t *testing.T) {
+			set := seq.NewHashSet(hasher, []int{1}, []int{1, 2})
+			assert.Equal(t, 2, set.Len())
+
+			assert.True(t, set.Add([]int{2}))
+			assert.False(t, set.Add([]int{1, 2}))
+			assert.Equal(t, 3, set.Len())
+
+			assert.True(t, set.Contains([]int{1}))
+			assert.False(t, set.Contains([]int{2, 1}))
+
+			assert.True(t, set.Remove([]int{1}))
+			assert.False(t, set.Remove([]int{1}))
+			assert.False(t, set.Contains([]int{1}))
+			assert.True(t, set.Contains([]int{1, 2}))
+			assert.Equal(t, 2, set.Len())
+
+			assert.ElementsMatch(t, [][]int{{1, 2}, {2}}, seq.ToSlice(set.Values()))
+		})
+	}
+}
+
+func Test_CollectHashSet(t *testing.T) {
+	set := seq.CollectHashSet(seq.Yield([]int{1}, []int{2}, []int{1}), sliceHasher)
+
+	assert.Equal(t, 2, set.Len())
+	assert.ElementsMatch(t, [][]int{{1}, {2}}, seq.ToSlice(set.Values()))
+
+	assert.Equal(t, 0, seq.CollectHashSet(seq.Empty[[]int](), sliceHasher).Len())
+}
+
+func Test_DistinctHash(t *testing.T) {
+	tests := []struct {
+		name string
+		seq  iter.Seq[[]int]
+		want [][]int
+	}{
+		{
+			name: "distinct values",
+			seq:  seq.Yield([]int{1}, []int{1, 2}, []int{1}, nil, []int{}),
+			want: [][]int{{1}, {1, 2}, nil},
+		},
+		{
+			name: "empty",
+			seq:  seq.Empty[[]int](),
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := seq.DistinctHash(tt.seq, sliceHasher)
+			seqtest.AssertEqual(t, tt.want, got)
+		})
+	}
+}
+
+func Test_ExceptHash(t *testing.T) {
+	vals := seq.NewHashSet(sliceHasher, []int{2})
+	got := seq.ExceptHash(seq.Yield([]int{1}, []int{2}, []int{3}, []int{1}), vals)
+
+	seqtest.AssertEqual(t, [][]int{{1}, {3}}, got)
+}
+
+func Test_IntersectHash(t *testing.T) {
+	vals := seq.NewHashSet(sliceHasher, []int{1}, []int{3})
+	got := seq.IntersectHash(seq.Yield([]int{1}, []int{2}, []int{3}, []int{1}), vals)
+
+	seqtest.AssertEqual(t, [][]int{{1}, {3}}, got)
+}
+
+func Test_UnionHash(t *testing.T) {
+	got := seq.UnionHash(sliceHasher, seq.Yield([]int{1}, []int{2}), seq.Yield([]int{2}, []int{3}))
+	seqtest.AssertEqual(t, [][]int{{1}, {2}, {3}}, got)
+
+	seqtest.AssertEqual(t, nil, seq.UnionHash(sliceHasher))
+}
@@ -0,0 +1,118 @@
+package seq
+
+import (
+	"errors"
+	"iter"
+	"math"
+	"math/bits"
+)
+
+// defaultHyperLogLogPrecision is the precision used by [CountDistinctApprox].
+// It uses 16 KiB of registers for a standard error of about 0.8%.
+const defaultHyperLogLogPrecision = 14
+
+// HyperLogLog estimates the number of distinct values in a stream using a fixed amount of memory.
+//
+// The standard error of the estimate is about 1.04/sqrt(2^precision).
+type HyperLogLog[V any] struct {
+	hash      func(V) uint64
+	registers []uint8
+	precision uint8
+}
+
+// NewHyperLogLog creates a HyperLogLog sketch with 2^precision registers.
+//
+// This panics if precision is not between 4 and 18.
+func NewHyperLogLog[V comparable](precision uint8) *HyperLogLog[V] {
+	return NewHyperLogLogFunc(precision, hashComparable[V])
+}
+
+// NewHyperLogLogFunc creates a HyperLogLog sketch with 2^precision registers using a function
+// to hash values.
+//
+// Sketches can only be merged if they use the same hash function.
+// This panics if precision is not between 4 and 18.
+func NewHyperLogLogFunc[V any](precision uint8, hash func(V) uint64) *HyperLogLog[V] {
+	if precision < 4 || precision > 18 {
+		panic("seq.NewHyperLogLog: precision must be between 4 and 18")
+	}
+
+	return &HyperLogLog[V]{
+		hash:      hash,
+		registers: make([]uint8, 1<<precision),
+		precision: precision,
+	}
+}
+
+// Add adds a value to the sketch.
+func (h *HyperLogLog[V]) Add(v V) {
+	x := mix64(h.hash(v))
+
+	// the first bits select the register, the rest count leading zeros
+	i := x >> (64 - h.precision)
+	w := x<<h.precision | 1<<(h.precision-1)
+	rank := uint8(bits.LeadingZeros64(w)) + 1
+
+	h.registers[i] = max(h.registers[i], rank)
+}
+
+// Count returns the estimated number of distinct values added to the sketch.
+func (h *HyperLogLog[V]) Count() uint64 {
+	m := float64(len(h.registers))
+	sum := 0.0
+	zeros := 0
+
+	for _, r := range h.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	alpha := 0.7213 / (1 + 1.079/m)
+	estimate := alpha * m * m / sum
+
+	// use linear counting for small cardinalities
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+
+	return uint64(math.Round(estimate))
+}
+
+// Merge adds the values of another sketch to this sketch.
+//
+// Both sketches must have been created with the same precision and hash function.
+func (h *HyperLogLog[V]) Merge(other *HyperLogLog[V]) error {
+	if h.precision != other.precision {
+		return errors.New("seq.HyperLogLog: cannot merge sketches with different precisions")
+	}
+
+	for i, r := range other.registers {
+		h.registers[i] = max(h.registers[i], r)
+	}
+
+	return nil
+}
+
+// CountDistinctApprox estimates the number of distinct values in a sequence using a fixed
+// amount of memory.
+//
+// The estimate has a standard error of about 0.8%.
+// Use [Count] with [Distinct] instead if an exact count is required.
+func CountDistinctApprox[V comparable](seq iter.Seq[V]) uint64 {
+	return CountDistinctApproxFunc(seq, hashComparable[V])
+}
+
+// CountDistinctApproxFunc estimates the number of distinct values in a sequence using a fixed
+// amount of memory and a function to hash values.
+//
+// See [CountDistinctApprox] for details.
+func CountDistinctApproxFunc[V any](seq iter.Seq[V], hash func(V) uint64) uint64 {
+	h := NewHyperLogLogFunc(defaultHyperLogLogPrecision, hash)
+	for v := range seq {
+		h.Add(v)
+	}
+
+	return h.Count()
+}
@@ -0,0 +1,77 @@
+package seq_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/arielsrv/go-seq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hashInt hashes an int with the SplitMix64 finalizer, so that sketches built in tests do not
+// depend on the random seed of the default hash.
+func hashInt(i int) uint64 {
+	h := uint64(i) + 0x9e3779b97f4a7c15
+	h = (h ^ h>>30) * 0xbf58476d1ce4e5b9
+	h = (h ^ h>>27) * 0x94d049bb133111eb
+
+	return h ^ h>>31
+}
+
+func Test_CountDistinctApprox(t *testing.T) {
+	tests := []struct {
+		name      string
+		n         int
+		tolerance float64
+	}{
+		{name: "empty", n: 0, tolerance: 0},
+		{name: "small", n: 100, tolerance: 2},
+		{name: "medium", n: 10000, tolerance: 300},
+		{name: "large", n: 200000, tolerance: 6000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// every value is repeated twice
+			vals := seq.Concat(seq.Take(naturals(), tt.n), seq.Take(naturals(), tt.n))
+			got := seq.CountDistinctApproxFunc(vals, hashInt)
+			assert.InDelta(t, tt.n, got, tt.tolerance)
+		})
+	}
+
+	t.Run("default hash", func(t *testing.T) {
+		// the default hash is seeded randomly, so allow for many standard errors
+		got := seq.CountDistinctApprox(seq.Take(naturals(), 10000))
+		assert.InDelta(t, 10000, got, 1000)
+	})
+}
+
+func Test_CountDistinctApproxFunc(t *testing.T) {
+	vals := seq.Select(seq.Take(naturals(), 1000), func(i int) []byte {
+		return []byte(strconv.Itoa(i % 500))
+	})
+
+	got := seq.CountDistinctApproxFunc(vals, hashBytes)
+	assert.InDelta(t, 500, got, 15)
+}
+
+func Test_HyperLogLog_Merge(t *testing.T) {
+	a := seq.NewHyperLogLogFunc(12, hashInt)
+	b := seq.NewHyperLogLogFunc(12, hashInt)
+
+	for i := range 5000 {
+		a.Add(i)
+		b.Add(i + 2500)
+	}
+
+	require.NoError(t, a.Merge(b))
+	assert.InDelta(t, 7500, a.Count(), 300)
+
+	assert.Error(t, a.Merge(seq.NewHyperLogLogFunc(10, hashInt)))
+}
+
+func Test_NewHyperLogLog_Panics(t *testing.T) {
+	assert.Panics(t, func() { seq.NewHyperLogLog[int](3) })
+	assert.Panics(t, func() { seq.NewHyperLogLog[int](19) })
+}
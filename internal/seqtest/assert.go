@@ -5,6 +5,7 @@ import (
 	"slices"
 	"testing"
 
+	"github.com/arielsrv/go-seq"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -17,10 +18,7 @@ func AssertEqual[V any](t *testing.T, expected []V, seq iter.Seq[V]) {
 }
 
 // KeyValuePair represents a value and its key.
-type KeyValuePair[K, V any] struct {
-	Key   K
-	Value V
-}
+type KeyValuePair[K, V any] = seq.Pair[K, V]
 
 // AssertEqual2 asserts that the expected key-value pairs are equal to the actual key-value pairs from a sequence.
 func AssertEqual2[K, V any](t *testing.T, expected []KeyValuePair[K, V], seq iter.Seq2[K, V]) {
@@ -28,7 +26,7 @@ func AssertEqual2[K, V any](t *testing.T, expected []KeyValuePair[K, V], seq ite
 
 	var actual []KeyValuePair[K, V]
 	for k, v := range seq {
-		actual = append(actual, KeyValuePair[K, V]{k, v})
+		actual = append(actual, KeyValuePair[K, V]{Key: k, Value: v})
 	}
 
 	assert.Equal(t, expected, actual, "sequence did not yield the expected key-value pairs")
@@ -41,8 +39,21 @@ func AssertElementsMatch2[K, V any](t *testing.T, expected []KeyValuePair[K, V],
 
 	var actual []KeyValuePair[K, V]
 	for k, v := range seq {
-		actual = append(actual, KeyValuePair[K, V]{k, v})
+		actual = append(actual, KeyValuePair[K, V]{Key: k, Value: v})
 	}
 
 	assert.ElementsMatch(t, expected, actual, "sequence did not yield the expected key-value pairs")
 }
+
+// NewCursorTracker creates a cursor tracker that asserts that every cursor it tracks is closed
+// by the end of the test.
+func NewCursorTracker(t *testing.T) *seq.CursorTracker {
+	t.Helper()
+
+	tracker := new(seq.CursorTracker)
+	t.Cleanup(func() {
+		assert.Equal(t, 0, tracker.Open(), "cursors were created but not closed")
+	})
+
+	return tracker
+}
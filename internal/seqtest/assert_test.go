@@ -4,6 +4,7 @@ import (
 	"iter"
 	"testing"
 
+	"github.com/arielsrv/go-seq"
 	"github.com/arielsrv/go-seq/internal/seqtest"
 )
 
@@ -16,7 +17,7 @@ func Test_AssertEqual(t *testing.T) {
 }
 
 func Test_AssertEqual2(t *testing.T) {
-	expected := []seqtest.KeyValuePair[string, int]{{"a", 1}, {"b", 2}}
+	expected := []seqtest.KeyValuePair[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}}
 	seq := iter.Seq2[string, int](func(yield func(string, int) bool) {
 		yield("a", 1)
 		yield("b", 2)
@@ -25,10 +26,19 @@ func Test_AssertEqual2(t *testing.T) {
 }
 
 func Test_AssertElementsMatch2(t *testing.T) {
-	expected := []seqtest.KeyValuePair[string, int]{{"a", 1}, {"b", 2}}
+	expected := []seqtest.KeyValuePair[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}}
 	seq := iter.Seq2[string, int](func(yield func(string, int) bool) {
 		yield("b", 2)
 		yield("a", 1)
 	})
 	seqtest.AssertElementsMatch2(t, expected, seq)
 }
+
+func Test_NewCursorTracker(t *testing.T) {
+	tracker := seqtest.NewCursorTracker(t)
+
+	c := seq.NewTrackedCursor(seq.Yield(1, 2, 3), tracker)
+	defer c.Close()
+
+	c.Next()
+}
@@ -32,6 +32,34 @@ func AggregateGrouped[K comparable, V, A any](
 	return groups
 }
 
+// AggregateGroupedWith computes an aggregation over the values of a sequence of key-value pairs
+// for each key.
+//
+// Example:
+//
+//	// average value per key
+//	avgs := seq.AggregateGroupedWith(kvs, seq.AverageAggregator[float64]())
+func AggregateGroupedWith[K comparable, V, R any](seq iter.Seq2[K, V], agg Aggregator[V, R]) map[K]R {
+	accs := make(map[K]Accumulator[V, R])
+
+	for k, v := range seq {
+		acc, ok := accs[k]
+		if !ok {
+			acc = agg()
+			accs[k] = acc
+		}
+
+		acc.Add(v)
+	}
+
+	groups := make(map[K]R, len(accs))
+	for k, acc := range accs {
+		groups[k] = acc.Result()
+	}
+
+	return groups
+}
+
 // CountGrouped counts the number of occurrences of each key in a sequence of key-value pairs.
 func CountGrouped[K comparable, V any](seq iter.Seq2[K, V]) map[K]int {
 	groups := make(map[K]int)
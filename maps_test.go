@@ -49,6 +49,47 @@ func Test_AggregateGrouped(t *testing.T) {
 	}
 }
 
+func Test_AggregateGroupedWith(t *testing.T) {
+	type stats struct {
+		count, sum int
+	}
+
+	countAndSum := seq.Combine2(
+		seq.CountAggregator[int](),
+		seq.SumAggregator[int](),
+		func(count, sum int) stats { return stats{count, sum} },
+	)
+
+	tests := []struct {
+		name     string
+		seq      iter.Seq2[string, int]
+		expected map[string]stats
+	}{
+		{
+			name:     "single group",
+			seq:      seq.Zip(seq.Yield("a", "a", "a"), seq.Yield(1, 2, 3)),
+			expected: map[string]stats{"a": {count: 3, sum: 6}},
+		},
+		{
+			name:     "multiple groups",
+			seq:      seq.Zip(seq.Yield("a", "b", "a"), seq.Yield(1, 2, 3)),
+			expected: map[string]stats{"a": {count: 2, sum: 4}, "b": {count: 1, sum: 2}},
+		},
+		{
+			name:     "empty sequence",
+			seq:      seq.Empty2[string, int](),
+			expected: map[string]stats{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := seq.AggregateGroupedWith(tt.seq, countAndSum)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
 func Test_CountGrouped(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,185 @@
+package seq
+
+import (
+	"errors"
+	"iter"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrMemoizeLimitExceeded is the value [MemoizeLimit] panics with when a sequence has more values
+// than the limit.
+var ErrMemoizeLimitExceeded = errors.New("seq.MemoizeLimit: sequence exceeded the memoize limit")
+
+// Memoize returns a sequence that records the values of a sequence the first time they are
+// yielded and replays them for subsequent iterations, and a function to stop the given sequence.
+//
+// The given sequence is iterated at most once, and only as far as the furthest iteration has
+// read, so this allows single-use sequences, such as those from [YieldChan], to be iterated
+// many times. Iterations can be partial, and can run concurrently from multiple goroutines.
+//
+// If no iteration reaches the end of the given sequence, it is left suspended, along with any
+// resources it holds, until the stop function is called. After stop, iterations only yield the
+// values recorded so far. It is safe to call stop more than once, and it is not necessary to call
+// it once an iteration has reached the end.
+//
+// All values are kept in memory. Use [MemoizeLimit] to bound the number of recorded values.
+func Memoize[V any](seq iter.Seq[V]) (iter.Seq[V], func()) {
+	return memoize(seq, -1)
+}
+
+// MemoizeLimit is like [Memoize], but records at most limit values.
+//
+// Iterating past the limit panics with [ErrMemoizeLimitExceeded].
+// This panics if limit is negative.
+func MemoizeLimit[V any](seq iter.Seq[V], limit int) (iter.Seq[V], func()) {
+	if limit < 0 {
+		panic("seq.MemoizeLimit: limit must be non-negative")
+	}
+
+	return memoize(seq, limit)
+}
+
+// memoize returns a memoized sequence and its stop function; a negative limit means no limit.
+func memoize[V any](seq iter.Seq[V], limit int) (iter.Seq[V], func()) {
+	m := &memo[V]{seq: seq, limit: limit}
+	m.cond = sync.NewCond(&m.mu)
+
+	memoized := func(yield func(V) bool) {
+		for i := 0; ; i++ {
+			v, ok := m.at(i)
+			if !ok {
+				return
+			}
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+
+	return memoized, m.release
+}
+
+// memo holds the recorded values of a memoized sequence.
+//
+// Values are read from the sequence without holding the lock, so that recorded values can be
+// replayed while a value is being read; reading is set meanwhile, and cond is signalled when
+// the read finishes.
+type memo[V any] struct {
+	seq      iter.Seq[V]
+	next     func() (V, bool)
+	stop     func()
+	vals     []V
+	limit    int
+	mu       sync.Mutex
+	cond     *sync.Cond
+	reading  bool
+	done     bool
+	exceeded bool
+}
+
+// at returns the value at index i, reading it from the sequence if it was not recorded yet.
+//
+// Each iteration reads indexes in order, so i is never past the number of recorded values.
+func (m *memo[V]) at(i int) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// wait for a value that is being read by another iteration
+	for m.reading && i >= len(m.vals) {
+		m.cond.Wait()
+	}
+
+	if i < len(m.vals) {
+		return m.vals[i], true
+	}
+
+	if m.exceeded {
+		panic(ErrMemoizeLimitExceeded)
+	}
+
+	var zero V
+	if m.done {
+		return zero, false
+	}
+
+	if m.next == nil {
+		m.next, m.stop = iter.Pull(m.seq)
+	}
+
+	v, ok := m.read()
+	if !ok {
+		m.done = true
+		m.stop()
+
+		return zero, false
+	}
+
+	if m.limit >= 0 && len(m.vals) >= m.limit {
+		m.exceeded = true
+		m.stop()
+		panic(ErrMemoizeLimitExceeded)
+	}
+
+	m.vals = append(m.vals, v)
+
+	if m.done {
+		// the stop function was called during the read
+		m.stop()
+	}
+
+	return v, true
+}
+
+// read reads the next value from the sequence, releasing the lock while it waits for the value.
+//
+// The lock must be held, and is held again when this returns, even if the sequence panics.
+func (m *memo[V]) read() (V, bool) {
+	m.reading = true
+	next := m.next
+	m.mu.Unlock()
+
+	defer func() {
+		m.mu.Lock()
+		m.reading = false
+		m.cond.Broadcast()
+	}()
+
+	return next()
+}
+
+// release stops the sequence if it is suspended, so that no more values are read from it.
+//
+// If a value is being read, the sequence is stopped once the read finishes.
+func (m *memo[V]) release() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.stop != nil && !m.reading {
+		m.stop()
+	}
+
+	m.done = true
+}
+
+// Once returns a sequence that can only be iterated once.
+//
+// This panics if the sequence is iterated a second time, which helps to catch bugs where
+// a single-use sequence, such as one from [YieldChan], is consumed more than once.
+// Use [Memoize] instead if the sequence needs to be iterated more than once.
+func Once[V any](seq iter.Seq[V]) iter.Seq[V] {
+	var used atomic.Bool
+
+	return func(yield func(V) bool) {
+		if used.Swap(true) {
+			panic("seq.Once: sequence was iterated more than once")
+		}
+
+		for v := range seq {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package seq_test
+
+import (
+	"iter"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/arielsrv/go-seq"
+	"github.com/arielsrv/go-seq/internal/seqtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingYield returns a sequence of values and a counter of how many values were read from it.
+func countingYield[V any](vals ...V) (iter.Seq[V], *int) {
+	reads := 0
+
+	return func(yield func(V) bool) {
+		for _, v := range vals {
+			reads++
+			if !yield(v) {
+				return
+			}
+		}
+	}, &reads
+}
+
+func Test_Memoize(t *testing.T) {
+	t.Run("replays single-use sequence", func(t *testing.T) {
+		ch := make(chan int, 3)
+		ch <- 1
+		ch <- 2
+		ch <- 3
+		close(ch)
+
+		memo, _ := seq.Memoize(seq.YieldChan(ch))
+
+		assert.Equal(t, 3, seq.Count(memo))
+		seqtest.AssertEqual(t, []int{1, 2, 3}, memo)
+		seqtest.AssertEqual(t, []int{1, 2, 3}, memo)
+	})
+
+	t.Run("reads lazily", func(t *testing.T) {
+		source, reads := countingYield(1, 2, 3, 4)
+		memo, _ := seq.Memoize(source)
+
+		assert.Equal(t, 0, *reads)
+
+		assert.Equal(t, []int{1, 2}, limitedCollector(memo, 2))
+		assert.Equal(t, 2, *reads)
+
+		assert.Equal(t, []int{1}, limitedCollector(memo, 1))
+		assert.Equal(t, 2, *reads, "recorded values are replayed")
+
+		seqtest.AssertEqual(t, []int{1, 2, 3, 4}, memo)
+		seqtest.AssertEqual(t, []int{1, 2, 3, 4}, memo)
+		assert.Equal(t, 4, *reads, "the source is read only once")
+	})
+
+	t.Run("interleaved iterations", func(t *testing.T) {
+		memo, _ := seq.Memoize(seq.Yield(1, 2, 3))
+
+		var got [][2]int
+		for a := range memo {
+			for b := range memo {
+				got = append(got, [2]int{a, b})
+			}
+		}
+
+		assert.Len(t, got, 9)
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		stopped := false
+		source := func(yield func(int) bool) {
+			defer func() { stopped = true }()
+
+			for v := range naturals() {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+
+		memo, stop := seq.Memoize(source)
+		assert.Equal(t, []int{0, 1}, limitedCollector(memo, 2))
+
+		stop()
+		assert.True(t, stopped, "stop stops the sequence")
+
+		seqtest.AssertEqual(t, []int{0, 1}, memo)
+		assert.NotPanics(t, stop)
+	})
+
+	t.Run("stop before iterating", func(t *testing.T) {
+		source, reads := countingYield(1, 2)
+		memo, stop := seq.Memoize(source)
+
+		stop()
+		seqtest.AssertEqual(t, nil, memo)
+		assert.Equal(t, 0, *reads)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		memo, _ := seq.Memoize(seq.Empty[int]())
+		seqtest.AssertEqual(t, nil, memo)
+		seqtest.AssertEqual(t, nil, memo)
+	})
+}
+
+func Test_Memoize_Concurrent(t *testing.T) {
+	source, reads := countingYield(seq.ToSlice(seq.Take(naturals(), 1000))...)
+	memo, _ := seq.Memoize(source)
+
+	var wg sync.WaitGroup
+	results := make([]int, 8)
+
+	for i := range results {
+		wg.Go(func() {
+			results[i] = seq.Sum(memo)
+		})
+	}
+
+	wg.Wait()
+
+	for _, sum := range results {
+		assert.Equal(t, 999*1000/2, sum)
+	}
+
+	assert.Equal(t, 1000, *reads)
+}
+
+func Test_Memoize_ReadInProgress(t *testing.T) {
+	ch := make(chan int, 1)
+	ch <- 0
+
+	memo, stop := seq.Memoize(seq.YieldChan(ch))
+	assert.Equal(t, []int{0}, limitedCollector(memo, 1))
+
+	// this iteration waits for the next value from the channel
+	reader := make(chan []int)
+	go func() { reader <- limitedCollector(memo, 2) }()
+
+	time.Sleep(10 * time.Millisecond)
+
+	notBlocked := func(name string, f func()) {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			f()
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatalf("%s waited for the value being read", name)
+		}
+	}
+
+	notBlocked("replaying", func() { assert.Equal(t, []int{0}, limitedCollector(memo, 1)) })
+	notBlocked("stop", stop)
+
+	// the value being read is still recorded, and the sequence is stopped after it
+	ch <- 1
+	assert.Equal(t, []int{0, 1}, <-reader)
+	seqtest.AssertEqual(t, []int{0, 1}, memo)
+}
+
+func Test_MemoizeLimit(t *testing.T) {
+	t.Run("within limit", func(t *testing.T) {
+		memo, _ := seq.MemoizeLimit(seq.Yield(1, 2, 3), 3)
+		seqtest.AssertEqual(t, []int{1, 2, 3}, memo)
+		seqtest.AssertEqual(t, []int{1, 2, 3}, memo)
+	})
+
+	t.Run("partial iteration within limit", func(t *testing.T) {
+		memo, _ := seq.MemoizeLimit(seq.Yield(1, 2, 3), 2)
+		assert.Equal(t, []int{1, 2}, limitedCollector(memo, 2))
+	})
+
+	t.Run("exceeded", func(t *testing.T) {
+		memo, _ := seq.MemoizeLimit(seq.Yield(1, 2, 3), 2)
+
+		assert.PanicsWithValue(t, seq.ErrMemoizeLimitExceeded, func() { seq.Count(memo) })
+		assert.PanicsWithValue(t, seq.ErrMemoizeLimitExceeded, func() { seq.Count(memo) })
+	})
+
+	assert.Panics(t, func() { seq.MemoizeLimit(seq.Yield(1), -1) })
+}
+
+func Test_Once(t *testing.T) {
+	once := seq.Once(seq.Yield(1, 2, 3))
+
+	seqtest.AssertEqual(t, []int{1, 2, 3}, once)
+	assert.PanicsWithValue(t, "seq.Once: sequence was iterated more than once", func() {
+		seq.Count(once)
+	})
+
+	t.Run("partial iteration counts as use", func(t *testing.T) {
+		once := seq.Once(seq.Yield(1, 2, 3))
+
+		assert.Equal(t, []int{1}, limitedCollector(once, 1))
+		assert.Panics(t, func() { seq.Count(once) })
+	})
+}
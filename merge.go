@@ -0,0 +1,182 @@
+package seq
+
+import (
+	"context"
+	"iter"
+	"reflect"
+	"sync"
+)
+
+// MergeChans returns a sequence of the values received from multiple channels, in the order
+// they arrive.
+//
+// Each channel is read in its own goroutine. The sequence ends when every channel is closed or
+// the context is done. When the sequence stops, it waits for its goroutines to stop, but it
+// does not drain the channels.
+// Use [MergeChansPriority] to prefer some channels over others.
+func MergeChans[V any](ctx context.Context, chans ...<-chan V) iter.Seq[V] {
+	sources := make([]func(context.Context) iter.Seq[V], len(chans))
+	for i, ch := range chans {
+		sources[i] = func(ctx context.Context) iter.Seq[V] { return yieldChanContext(ctx, ch) }
+	}
+
+	return mergeConcurrent(ctx, sources)
+}
+
+// MergeConcurrent returns a sequence of the values of multiple sequences, in the order they
+// are yielded.
+//
+// Each sequence is iterated in its own goroutine. When the returned sequence stops, every
+// sequence is stopped the next time it yields a value, and the returned sequence waits for
+// them to return. If a sequence panics, the others are stopped and the panic is raised again
+// by the returned sequence.
+func MergeConcurrent[V any](seqs ...iter.Seq[V]) iter.Seq[V] {
+	sources := make([]func(context.Context) iter.Seq[V], len(seqs))
+	for i, seq := range seqs {
+		sources[i] = func(context.Context) iter.Seq[V] { return seq }
+	}
+
+	return mergeConcurrent(context.Background(), sources)
+}
+
+// mergeConcurrent implements [MergeChans] and [MergeConcurrent].
+//
+// Each source is called with a context that is done when the returned sequence stops.
+func mergeConcurrent[V any](ctx context.Context, sources []func(context.Context) iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+
+		out := make(chan V)
+		var panicked goroutinePanic
+		var wg sync.WaitGroup
+
+		for _, source := range sources {
+			// stop the other sequences if this one panics
+			panicked.goCancel(&wg, cancel, func() {
+				for v := range source(ctx) {
+					select {
+					case out <- v:
+					case <-ctx.Done():
+						return
+					}
+				}
+			})
+		}
+
+		go func() {
+			wg.Wait()
+			close(out)
+		}()
+
+		defer func() {
+			cancel()
+
+			// wait for the sequences to stop
+			for range out {
+			}
+
+			panicked.repanic()
+		}()
+
+		for v := range out {
+			if ctx.Err() != nil || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// yieldChanContext returns a sequence of values from a channel that ends when the context is done.
+func yieldChanContext[V any](ctx context.Context, ch <-chan V) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for {
+			select {
+			case v, ok := <-ch:
+				if !ok || !yield(v) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// MergeChansPriority returns a sequence of the values received from multiple channels,
+// preferring earlier channels over later ones.
+//
+// Whenever values are available from more than one channel, the value from the earliest channel
+// is received first, so a busy high-priority channel is drained before lower-priority channels
+// are read. The channels are read from the goroutine that iterates the sequence.
+// The sequence ends when every channel is closed or the context is done.
+func MergeChansPriority[V any](ctx context.Context, chans ...<-chan V) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		open := make([]<-chan V, len(chans))
+		copy(open, chans)
+
+		// the first case waits for the context; the others receive from each channel
+		cases := make([]reflect.SelectCase, len(chans)+1)
+		cases[0] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())}
+
+		for i, ch := range chans {
+			cases[i+1] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ch)}
+		}
+
+		remaining := len(chans)
+
+		closeChan := func(i int) {
+			open[i] = nil
+			cases[i+1].Chan = reflect.Value{}
+			remaining--
+		}
+
+		for remaining > 0 {
+			if ctx.Err() != nil {
+				return
+			}
+
+			v, i, ok := receivePriority(open)
+			if i < 0 {
+				// nothing is ready yet, so wait for any channel
+				chosen, recv, recvOK := reflect.Select(cases)
+				if chosen == 0 {
+					return
+				}
+
+				i, ok = chosen-1, recvOK
+				if ok {
+					v, _ = recv.Interface().(V)
+				}
+			}
+
+			if !ok {
+				closeChan(i)
+				continue
+			}
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// receivePriority receives a value from the first channel that is ready without blocking.
+// Returns the index of the channel, or -1 if none are ready.
+func receivePriority[V any](chans []<-chan V) (V, int, bool) {
+	for i, ch := range chans {
+		if ch == nil {
+			continue
+		}
+
+		select {
+		case v, ok := <-ch:
+			return v, i, ok
+		default:
+		}
+	}
+
+	var zero V
+
+	return zero, -1, false
+}
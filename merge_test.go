@@ -0,0 +1,163 @@
+package seq_test
+
+import (
+	"context"
+	"iter"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/arielsrv/go-seq"
+	"github.com/stretchr/testify/assert"
+)
+
+// sendAll returns a closed channel with the given values buffered.
+func sendAll[V any](vals ...V) <-chan V {
+	ch := make(chan V, len(vals))
+	for _, v := range vals {
+		ch <- v
+	}
+	close(ch)
+
+	return ch
+}
+
+func Test_MergeChans(t *testing.T) {
+	t.Run("all values", func(t *testing.T) {
+		got := seq.MergeChans(context.Background(), sendAll(1, 2, 3), sendAll(4, 5), sendAll[int]())
+		assert.ElementsMatch(t, []int{1, 2, 3, 4, 5}, seq.ToSlice(got))
+	})
+
+	t.Run("order within a channel", func(t *testing.T) {
+		got := seq.ToSlice(seq.MergeChans(context.Background(), sendAll(1, 2, 3), sendAll(-1, -2, -3)))
+
+		positives := seq.ToSlice(seq.Where(seq.Yield(got...), func(v int) bool { return v > 0 }))
+		assert.Equal(t, []int{1, 2, 3}, positives)
+	})
+
+	t.Run("no channels", func(t *testing.T) {
+		assert.Empty(t, seq.ToSlice(seq.MergeChans[int](context.Background())))
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		ch := make(chan int)
+		go func() {
+			for i := 0; ; i++ {
+				select {
+				case ch <- i:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		// ch is never closed, so the sequence ends because the context is done
+		var got []int
+		for v := range seq.MergeChans(ctx, ch) {
+			got = append(got, v)
+			if v == 2 {
+				cancel()
+			}
+		}
+
+		assert.Equal(t, []int{0, 1, 2}, got)
+	})
+
+	t.Run("consumer stops early", func(t *testing.T) {
+		ch := make(chan int)
+		go func() {
+			for i := range 3 {
+				ch <- i
+			}
+		}()
+
+		got := limitedCollector(seq.MergeChans(context.Background(), ch, make(chan int)), 1)
+		assert.Equal(t, []int{0}, got)
+	})
+}
+
+func Test_MergeConcurrent(t *testing.T) {
+	t.Run("all values", func(t *testing.T) {
+		got := seq.MergeConcurrent(seq.Yield(1, 2, 3), seq.Yield(4, 5), seq.Empty[int]())
+		assert.ElementsMatch(t, []int{1, 2, 3, 4, 5}, seq.ToSlice(got))
+	})
+
+	t.Run("sources are stopped", func(t *testing.T) {
+		var running atomic.Int32
+
+		source := func(yield func(int) bool) {
+			running.Add(1)
+			defer running.Add(-1)
+
+			for v := range naturals() {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+
+		got := limitedCollector(seq.MergeConcurrent(source, source, source), 10)
+		assert.Len(t, got, 10)
+		assert.Equal(t, int32(0), running.Load(), "every source has returned")
+	})
+
+	t.Run("panic is propagated", func(t *testing.T) {
+		panicking := func(yield func(int) bool) {
+			yield(1)
+			panic("boom")
+		}
+
+		merged := seq.MergeConcurrent(naturals(), iter.Seq[int](panicking))
+		assert.PanicsWithValue(t, "boom", func() { seq.Count(merged) })
+	})
+}
+
+func Test_MergeChansPriority(t *testing.T) {
+	t.Run("drains higher priority first", func(t *testing.T) {
+		got := seq.MergeChansPriority(context.Background(), sendAll(1, 2, 3), sendAll(10, 20), sendAll(100))
+		assert.Equal(t, []int{1, 2, 3, 10, 20, 100}, seq.ToSlice(got))
+	})
+
+	t.Run("waits for values", func(t *testing.T) {
+		low := make(chan string)
+		high := make(chan string)
+
+		go func() {
+			low <- "low"
+			close(low)
+
+			high <- "high"
+			close(high)
+		}()
+
+		got := seq.MergeChansPriority(context.Background(), high, low)
+		assert.Equal(t, []string{"low", "high"}, seq.ToSlice(got))
+	})
+
+	t.Run("nil interface values", func(t *testing.T) {
+		ch := make(chan any)
+		go func() {
+			ch <- nil
+			close(ch)
+		}()
+
+		got := seq.MergeChansPriority(context.Background(), ch)
+		assert.Equal(t, []any{nil}, seq.ToSlice(got))
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		got := seq.MergeChansPriority(ctx, make(chan int))
+		assert.Empty(t, seq.ToSlice(got))
+	})
+
+	t.Run("early return", func(t *testing.T) {
+		got := seq.MergeChansPriority(context.Background(), sendAll(1, 2, 3))
+		assert.Equal(t, []int{1, 2}, limitedCollector(got, 2))
+	})
+}
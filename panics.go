@@ -0,0 +1,56 @@
+package seq
+
+import (
+	"sync"
+)
+
+// goroutinePanic records the first panic raised by background goroutines so that it can be
+// raised again on the goroutine that consumes their results.
+type goroutinePanic struct {
+	val any
+	mu  sync.Mutex
+	ok  bool
+}
+
+// capture recovers from a panic and records it if it is the first one.
+//
+// It must be called directly with defer.
+func (p *goroutinePanic) capture() {
+	if r := recover(); r != nil {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		if !p.ok {
+			p.val, p.ok = r, true
+		}
+	}
+}
+
+// goCancel calls f in a new goroutine added to wg, and calls cancel if f does not return
+// normally, so that other goroutines can stop. A panic raised by f is recorded as with capture.
+func (p *goroutinePanic) goCancel(wg *sync.WaitGroup, cancel func(), f func()) {
+	wg.Go(func() {
+		completed := false
+		defer func() {
+			if !completed {
+				cancel()
+			}
+		}()
+
+		defer p.capture()
+
+		f()
+		completed = true
+	})
+}
+
+// repanic panics with the recorded value, if any.
+func (p *goroutinePanic) repanic() {
+	p.mu.Lock()
+	val, ok := p.val, p.ok
+	p.mu.Unlock()
+
+	if ok {
+		panic(val)
+	}
+}
@@ -0,0 +1,431 @@
+package seq
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// partitionBuffer is the number of values that each worker of [PartitionedParallel] can have
+// waiting to be processed.
+const partitionBuffer = 16
+
+// PartitionedParallel returns a sequence of the results of calling a function with each value of
+// a sequence, using a number of worker goroutines.
+//
+// Each value is sent to a worker chosen by hashing its key, so values with equal keys are
+// always processed by the same worker, one at a time, and their results are yielded in the
+// order of the values. Results for different keys can be yielded in any order.
+//
+// Each worker has a bounded buffer, so when a worker falls behind, reading from the sequence
+// waits until the worker catches up. When the returned sequence stops, the given sequence and
+// the workers are stopped, and the returned sequence waits for them to return. If the sequence
+// or the function panics, the panic is raised again by the returned sequence.
+// This panics if workers is not positive.
+func PartitionedParallel[V any, K comparable, R any](
+	seq iter.Seq[V],
+	keyFunc func(V) K,
+	workers int,
+	f func(V) R,
+) iter.Seq[R] {
+	if workers <= 0 {
+		panic("seq.PartitionedParallel: workers must be positive")
+	}
+
+	return func(yield func(R) bool) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		ins := make([]chan V, workers)
+		out := make(chan R)
+
+		var panicked goroutinePanic
+		var wg sync.WaitGroup
+
+		for i := range ins {
+			ins[i] = make(chan V, partitionBuffer)
+
+			panicked.goCancel(&wg, cancel, func() {
+				for v := range ins[i] {
+					if ctx.Err() != nil {
+						return
+					}
+
+					select {
+					case out <- f(v):
+					case <-ctx.Done():
+						return
+					}
+				}
+			})
+		}
+
+		// send each value to the worker for its key
+		panicked.goCancel(&wg, cancel, func() {
+			defer func() {
+				for _, in := range ins {
+					close(in)
+				}
+			}()
+
+			for v := range seq {
+				in := ins[hashComparable(keyFunc(v))%uint64(workers)]
+
+				select {
+				case in <- v:
+				case <-ctx.Done():
+					return
+				}
+			}
+		})
+
+		go func() {
+			wg.Wait()
+			close(out)
+		}()
+
+		defer func() {
+			cancel()
+
+			// wait for the sequence and the workers to stop
+			for range out {
+			}
+
+			panicked.repanic()
+		}()
+
+		for r := range out {
+			if !yield(r) {
+				return
+			}
+		}
+	}
+}
+
+// parallelBatchSize is the number of values that [ParallelAggregate] and
+// [ParallelAggregateGrouped] send to a worker at a time.
+const parallelBatchSize = 256
+
+// pendingChunksPerWorker is the number of chunks per worker that [ParallelAggregateChunked] and
+// [ParallelAggregateGroupedChunked] can have read but not yet combined.
+const pendingChunksPerWorker = 2
+
+// ParallelAggregate applies an accumulator function over a sequence using a number of worker
+// goroutines.
+//
+// Each worker accumulates a partial result, starting from a new value returned by initFunc,
+// and the partial results are merged with the combine function. The values that each worker
+// receives depend on scheduling, so combine must be associative and commutative, and the
+// result can vary between runs when they are only approximately so, as with floating-point
+// sums. Use [ParallelAggregateChunked] for results that do not depend on scheduling.
+//
+// This returns a new value from initFunc if the sequence is empty. If the accumulator or combine
+// function panics, the panic is raised again by this function once the workers have stopped.
+// This panics if workers is not positive.
+func ParallelAggregate[V, A any](
+	seq iter.Seq[V],
+	workers int,
+	initFunc func() A,
+	f func(A, V) A,
+	combine func(A, A) A,
+) A {
+	if workers <= 0 {
+		panic("seq.ParallelAggregate: workers must be positive")
+	}
+
+	partials := make([]optional[A], workers)
+
+	parallelChunks(seq, workers, parallelBatchSize, nil, func(worker, _ int, chunk []V) {
+		p := &partials[worker]
+		if !p.ok {
+			p.val, p.ok = initFunc(), true
+		}
+
+		for _, v := range chunk {
+			p.val = f(p.val, v)
+		}
+	})
+
+	return combineOptionals(partials, combine, initFunc)
+}
+
+// ParallelAggregateChunked applies an accumulator function over a sequence using a number of
+// worker goroutines, with a result that does not depend on scheduling.
+//
+// The sequence is split into chunks of chunkSize values, each chunk is accumulated into a
+// partial result starting from a new value returned by initFunc, and the partial results are
+// merged with the combine function in the order of the chunks. The result is therefore the same
+// for every run with the same chunk size, even if combine is only approximately associative, as
+// with floating-point sums. Partial results wait until every earlier chunk is combined, so
+// reading from the sequence waits while twice as many chunks as workers are not yet combined.
+//
+// This returns a new value from initFunc if the sequence is empty. If the accumulator or combine
+// function panics, the panic is raised again by this function once the workers have stopped.
+// This panics if workers or chunkSize are not positive.
+func ParallelAggregateChunked[V, A any](
+	seq iter.Seq[V],
+	workers, chunkSize int,
+	initFunc func() A,
+	f func(A, V) A,
+	combine func(A, A) A,
+) A {
+	if workers <= 0 {
+		panic("seq.ParallelAggregateChunked: workers must be positive")
+	}
+
+	if chunkSize <= 0 {
+		panic("seq.ParallelAggregateChunked: chunkSize must be positive")
+	}
+
+	c := newOrderedCombiner(combine, workers*pendingChunksPerWorker)
+
+	parallelChunks(seq, workers, chunkSize, c.slots, func(_, index int, chunk []V) {
+		acc := initFunc()
+		for _, v := range chunk {
+			acc = f(acc, v)
+		}
+
+		c.add(index, acc)
+	})
+
+	if !c.acc.ok {
+		return initFunc()
+	}
+
+	return c.acc.val
+}
+
+// ParallelAggregateGrouped aggregates values from a sequence of key-value pairs into a map with
+// accumulated values grouped by key using a number of worker goroutines.
+//
+// This works like [AggregateGrouped], but each worker accumulates partial results for the keys it
+// receives, and the partial results for each key are merged with the combine function. As with
+// [ParallelAggregate], combine must be associative and commutative; use
+// [ParallelAggregateGroupedChunked] for results that do not depend on scheduling.
+// This panics if workers is not positive.
+func ParallelAggregateGrouped[K comparable, V, A any](
+	seq iter.Seq2[K, V],
+	workers int,
+	initFunc func(K) A,
+	f func(A, V) A,
+	combine func(A, A) A,
+) map[K]A {
+	if workers <= 0 {
+		panic("seq.ParallelAggregateGrouped: workers must be positive")
+	}
+
+	partials := make([]map[K]A, workers)
+	for i := range partials {
+		partials[i] = make(map[K]A)
+	}
+
+	parallelChunks(ToPairs(seq), workers, parallelBatchSize, nil, func(worker, _ int, chunk []Pair[K, V]) {
+		accumulateGrouped(partials[worker], chunk, initFunc, f)
+	})
+
+	groups := partials[0]
+	for _, partial := range partials[1:] {
+		mergeGrouped(groups, partial, combine)
+	}
+
+	return groups
+}
+
+// ParallelAggregateGroupedChunked aggregates values from a sequence of key-value pairs into
+// a map with accumulated values grouped by key using a number of worker goroutines, with
+// a result that does not depend on scheduling.
+//
+// The sequence is split into chunks as with [ParallelAggregateChunked], and the partial results
+// for each key are merged in the order of the chunks. As with [ParallelAggregateChunked], at most
+// twice as many chunks as workers are read but not yet merged.
+// This panics if workers or chunkSize are not positive.
+func ParallelAggregateGroupedChunked[K comparable, V, A any](
+	seq iter.Seq2[K, V],
+	workers, chunkSize int,
+	initFunc func(K) A,
+	f func(A, V) A,
+	combine func(A, A) A,
+) map[K]A {
+	if workers <= 0 {
+		panic("seq.ParallelAggregateGroupedChunked: workers must be positive")
+	}
+
+	if chunkSize <= 0 {
+		panic("seq.ParallelAggregateGroupedChunked: chunkSize must be positive")
+	}
+
+	c := newOrderedCombiner(func(dst, src map[K]A) map[K]A {
+		mergeGrouped(dst, src, combine)
+		return dst
+	}, workers*pendingChunksPerWorker)
+
+	parallelChunks(ToPairs(seq), workers, chunkSize, c.slots, func(_, index int, chunk []Pair[K, V]) {
+		groups := make(map[K]A)
+		accumulateGrouped(groups, chunk, initFunc, f)
+
+		c.add(index, groups)
+	})
+
+	if !c.acc.ok {
+		return make(map[K]A)
+	}
+
+	return c.acc.val
+}
+
+// parallelChunks splits a sequence into chunks of the given size and calls f with each chunk and
+// its index using a number of worker goroutines, passing the index of the worker that runs it.
+//
+// The sequence is iterated on the calling goroutine. If slots is not nil, a slot is taken before
+// each chunk is sent to a worker, and reading waits while there are no free slots; f is expected
+// to free them. If f panics, the other workers stop and the panic is raised again once they have
+// returned.
+func parallelChunks[V any](
+	seq iter.Seq[V],
+	workers, size int,
+	slots chan struct{},
+	f func(worker, index int, chunk []V),
+) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	type indexedChunk struct {
+		index int
+		vals  []V
+	}
+
+	chunks := make(chan indexedChunk, workers)
+
+	var panicked goroutinePanic
+	var wg sync.WaitGroup
+
+	for worker := range workers {
+		panicked.goCancel(&wg, cancel, func() {
+			for c := range chunks {
+				if ctx.Err() != nil {
+					return
+				}
+
+				f(worker, c.index, c.vals)
+			}
+		})
+	}
+
+	func() {
+		defer close(chunks)
+
+		index := 0
+		for chunk := range Chunk(seq, size) {
+			if slots != nil {
+				select {
+				case slots <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case chunks <- indexedChunk{index, chunk}:
+			case <-ctx.Done():
+				return
+			}
+
+			index++
+		}
+	}()
+
+	wg.Wait()
+	panicked.repanic()
+}
+
+// accumulateGrouped adds key-value pairs to a map of accumulated values grouped by key.
+func accumulateGrouped[K comparable, V, A any](groups map[K]A, pairs []Pair[K, V], initFunc func(K) A, f func(A, V) A) {
+	for _, p := range pairs {
+		acc, ok := groups[p.Key]
+		if !ok {
+			acc = initFunc(p.Key)
+		}
+
+		groups[p.Key] = f(acc, p.Value)
+	}
+}
+
+// mergeGrouped merges the accumulated values of src into dst, combining values with equal keys.
+func mergeGrouped[K comparable, A any](dst, src map[K]A, combine func(A, A) A) {
+	for k, a := range src {
+		if acc, ok := dst[k]; ok {
+			dst[k] = combine(acc, a)
+		} else {
+			dst[k] = a
+		}
+	}
+}
+
+// combineOptionals combines the values that are present, in order, or returns a new value from
+// initFunc if there are none.
+func combineOptionals[A any](vals []optional[A], combine func(A, A) A, initFunc func() A) A {
+	var acc optional[A]
+
+	for _, v := range vals {
+		switch {
+		case !v.ok:
+		case !acc.ok:
+			acc = v
+		default:
+			acc.val = combine(acc.val, v.val)
+		}
+	}
+
+	if !acc.ok {
+		return initFunc()
+	}
+
+	return acc.val
+}
+
+// orderedCombiner combines partial results in the order of their indexes, as soon as every
+// earlier partial result is available.
+type orderedCombiner[A any] struct {
+	combine func(A, A) A
+	mu      sync.Mutex
+	pending map[int]A
+	next    int
+	acc     optional[A]
+
+	// slots has a slot taken for each partial result that is expected but not yet combined
+	slots chan struct{}
+}
+
+// newOrderedCombiner creates an ordered combiner that allows the given number of partial results
+// to be expected but not yet combined.
+func newOrderedCombiner[A any](combine func(A, A) A, pending int) *orderedCombiner[A] {
+	return &orderedCombiner[A]{
+		combine: combine,
+		pending: make(map[int]A),
+		slots:   make(chan struct{}, pending),
+	}
+}
+
+// add adds the partial result with the given index, which must have taken a slot.
+func (c *orderedCombiner[A]) add(index int, a A) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pending[index] = a
+
+	for {
+		next, ok := c.pending[c.next]
+		if !ok {
+			return
+		}
+
+		delete(c.pending, c.next)
+		c.next++
+		<-c.slots
+
+		if c.acc.ok {
+			c.acc.val = c.combine(c.acc.val, next)
+		} else {
+			c.acc = optional[A]{val: next, ok: true}
+		}
+	}
+}
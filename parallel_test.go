@@ -0,0 +1,299 @@
+package seq_test
+
+import (
+	"iter"
+	"math"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/arielsrv/go-seq"
+	"github.com/stretchr/testify/assert"
+)
+
+// event is a value with a key and its position among the values with the same key.
+type event struct {
+	key string
+	pos int
+}
+
+// events returns n events spread over the given keys.
+func events(n int, keys ...string) iter.Seq[event] {
+	return func(yield func(event) bool) {
+		positions := make(map[string]int)
+
+		for i := range n {
+			key := keys[i%len(keys)]
+			if !yield(event{key, positions[key]}) {
+				return
+			}
+
+			positions[key]++
+		}
+	}
+}
+
+func Test_PartitionedParallel(t *testing.T) {
+	t.Run("preserves order per key", func(t *testing.T) {
+		rng := newRand(1)
+		delays := make([]time.Duration, 1000)
+		for i := range delays {
+			delays[i] = time.Duration(rng.IntN(50)) * time.Microsecond
+		}
+
+		keys := []string{"a", "b", "c", "d", "e", "f", "g"}
+		results := seq.PartitionedParallel(events(1000, keys...), func(e event) string { return e.key }, 4,
+			func(e event) event {
+				time.Sleep(delays[e.pos])
+				return e
+			})
+
+		next := make(map[string]int)
+		for e := range results {
+			assert.Equal(t, next[e.key], e.pos, "events for key %q are out of order", e.key)
+			next[e.key]++
+		}
+
+		assert.Equal(t, 1000, seq.Sum(seq.Values(seq.YieldKeyValues(next))))
+	})
+
+	t.Run("single worker", func(t *testing.T) {
+		got := seq.PartitionedParallel(seq.Yield(1, 2, 3), func(v int) int { return v }, 1,
+			func(v int) int { return v * 2 })
+
+		assert.Equal(t, []int{2, 4, 6}, seq.ToSlice(got))
+	})
+
+	t.Run("empty sequence", func(t *testing.T) {
+		got := seq.PartitionedParallel(seq.Empty[int](), func(v int) int { return v }, 4,
+			func(v int) int { return v })
+
+		assert.Empty(t, seq.ToSlice(got))
+	})
+
+	t.Run("backpressure", func(t *testing.T) {
+		var produced atomic.Int32
+
+		source := seq.Select(seq.Take(naturals(), 100), func(v int) int {
+			produced.Add(1)
+			return v
+		})
+
+		release := make(chan struct{})
+		results := seq.PartitionedParallel(source, func(int) string { return "slow" }, 4, func(v int) int {
+			<-release
+			return v
+		})
+
+		done := make(chan int)
+		go func() { done <- seq.Count(results) }()
+
+		time.Sleep(20 * time.Millisecond)
+		assert.LessOrEqual(t, produced.Load(), int32(20), "the source waits for the slow worker")
+
+		close(release)
+		assert.Equal(t, 100, <-done)
+	})
+
+	t.Run("consumer stops early", func(t *testing.T) {
+		source, stopped := stoppable()
+
+		got := seq.PartitionedParallel(source, func(v int) int { return v % 3 }, 3, func(v int) int { return v })
+		assert.Len(t, limitedCollector(got, 10), 10)
+
+		<-stopped
+	})
+
+	t.Run("panic is raised in consumer", func(t *testing.T) {
+		results := seq.PartitionedParallel(naturals(), func(v int) int { return v % 2 }, 2, func(v int) int {
+			if v == 5 {
+				panic("boom")
+			}
+
+			return v
+		})
+
+		assert.PanicsWithValue(t, "boom", func() { seq.Count(results) })
+	})
+
+	assert.Panics(t, func() {
+		seq.PartitionedParallel(seq.Yield(1), func(v int) int { return v }, 0, func(v int) int { return v })
+	})
+}
+
+// floats returns n floats of very different magnitudes, whose sum depends on the order they are added.
+func floats(n int) []float64 {
+	rng := newRand(1)
+
+	vals := make([]float64, n)
+	for i := range vals {
+		vals[i] = rng.Float64() * math.Pow(10, float64(rng.IntN(20)))
+	}
+
+	return vals
+}
+
+func add[V int | float64](a, b V) V {
+	return a + b
+}
+
+func Test_ParallelAggregate(t *testing.T) {
+	t.Run("sum", func(t *testing.T) {
+		vals := seq.ToSlice(seq.Take(naturals(), 10_000))
+
+		for _, workers := range []int{1, 3, 8} {
+			got := seq.ParallelAggregate(seq.Yield(vals...), workers, func() int { return 0 }, add, add)
+			assert.Equal(t, seq.Sum(seq.Yield(vals...)), got)
+		}
+	})
+
+	t.Run("collects into slices", func(t *testing.T) {
+		got := seq.ParallelAggregate(seq.Take(naturals(), 1000), 4,
+			func() []int { return nil },
+			func(acc []int, v int) []int { return append(acc, v) },
+			func(a, b []int) []int { return append(a, b...) })
+
+		assert.ElementsMatch(t, seq.ToSlice(seq.Take(naturals(), 1000)), got)
+	})
+
+	t.Run("empty sequence", func(t *testing.T) {
+		got := seq.ParallelAggregate(seq.Empty[int](), 4, func() int { return 42 }, add, add)
+		assert.Equal(t, 42, got)
+	})
+
+	t.Run("panic is raised", func(t *testing.T) {
+		assert.PanicsWithValue(t, "boom", func() {
+			seq.ParallelAggregate(seq.Take(naturals(), 10_000), 4, func() int { return 0 },
+				func(acc, v int) int {
+					if v == 5000 {
+						panic("boom")
+					}
+
+					return acc + v
+				}, add)
+		})
+	})
+
+	assert.Panics(t, func() { seq.ParallelAggregate(seq.Yield(1), 0, func() int { return 0 }, add, add) })
+}
+
+func Test_ParallelAggregateChunked(t *testing.T) {
+	t.Run("deterministic float sum", func(t *testing.T) {
+		vals := floats(10_000)
+
+		// the same chunks combined in order on a single goroutine
+		var want float64
+		for chunk := range seq.Chunk(seq.Yield(vals...), 100) {
+			want += seq.Sum(seq.Yield(chunk...))
+		}
+
+		for _, workers := range []int{1, 2, 8} {
+			for range 10 {
+				got := seq.ParallelAggregateChunked(seq.Yield(vals...), workers, 100, func() float64 { return 0 }, add, add)
+				assert.Equal(t, math.Float64bits(want), math.Float64bits(got))
+			}
+		}
+	})
+
+	t.Run("keeps order", func(t *testing.T) {
+		got := seq.ParallelAggregateChunked(seq.Take(naturals(), 1000), 4, 7,
+			func() string { return "" },
+			func(acc string, v int) string { return acc + strconv.Itoa(v) + "," },
+			func(a, b string) string { return a + b })
+
+		want := seq.Aggregate(seq.Take(naturals(), 1000), "", func(acc string, v int) string {
+			return acc + strconv.Itoa(v) + ","
+		})
+
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("empty sequence", func(t *testing.T) {
+		got := seq.ParallelAggregateChunked(seq.Empty[int](), 4, 10, func() int { return 42 }, add, add)
+		assert.Equal(t, 42, got)
+	})
+
+	t.Run("slow chunk limits reading", func(t *testing.T) {
+		var produced atomic.Int32
+
+		source := seq.Select(seq.Take(naturals(), 1000), func(v int) int {
+			produced.Add(1)
+			return v
+		})
+
+		release := make(chan struct{})
+		done := make(chan int)
+
+		go func() {
+			done <- seq.ParallelAggregateChunked(source, 2, 10, func() int { return 0 },
+				func(acc, v int) int {
+					if v == 0 {
+						<-release
+					}
+
+					return acc + v
+				}, add)
+		}()
+
+		time.Sleep(20 * time.Millisecond)
+		assert.LessOrEqual(t, produced.Load(), int32(50), "the sequence waits for the first chunk to be combined")
+
+		close(release)
+		assert.Equal(t, 999*1000/2, <-done)
+	})
+
+	assert.Panics(t, func() { seq.ParallelAggregateChunked(seq.Yield(1), 0, 1, func() int { return 0 }, add, add) })
+	assert.Panics(t, func() { seq.ParallelAggregateChunked(seq.Yield(1), 1, 0, func() int { return 0 }, add, add) })
+}
+
+func Test_ParallelAggregateGrouped(t *testing.T) {
+	pairs := seq.SelectKeys(seq.Take(naturals(), 10_000), func(v int) int { return v % 7 })
+	want := seq.AggregateGrouped(pairs, func(int) int { return 0 }, add)
+
+	for _, workers := range []int{1, 4} {
+		got := seq.ParallelAggregateGrouped(pairs, workers, func(int) int { return 0 }, add, add)
+		assert.Equal(t, want, got)
+	}
+
+	t.Run("empty sequence", func(t *testing.T) {
+		got := seq.ParallelAggregateGrouped(seq.Empty2[string, int](), 4, func(string) int { return 0 }, add, add)
+		assert.Empty(t, got)
+	})
+
+	assert.Panics(t, func() {
+		seq.ParallelAggregateGrouped(seq.Empty2[string, int](), 0, func(string) int { return 0 }, add, add)
+	})
+}
+
+func Test_ParallelAggregateGroupedChunked(t *testing.T) {
+	vals := floats(10_000)
+	pairs := seq.SelectKeys(seq.Yield(vals...), func(v float64) bool { return v < 1 })
+
+	first := seq.ParallelAggregateGroupedChunked(pairs, 8, 50, func(bool) float64 { return 0 }, add, add)
+	assert.Len(t, first, 2)
+
+	for range 10 {
+		got := seq.ParallelAggregateGroupedChunked(pairs, 8, 50, func(bool) float64 { return 0 }, add, add)
+		assert.Equal(t, first, got)
+	}
+
+	t.Run("keeps order", func(t *testing.T) {
+		pairs := seq.SelectKeys(seq.Take(naturals(), 1000), func(v int) int { return v % 3 })
+		concat := func(acc string, v int) string { return acc + strconv.Itoa(v) + "," }
+
+		got := seq.ParallelAggregateGroupedChunked(pairs, 4, 7, func(int) string { return "" }, concat,
+			func(a, b string) string { return a + b })
+
+		assert.Equal(t, seq.AggregateGrouped(pairs, func(int) string { return "" }, concat), got)
+	})
+
+	t.Run("empty sequence", func(t *testing.T) {
+		got := seq.ParallelAggregateGroupedChunked(seq.Empty2[string, int](), 4, 10, func(string) int { return 0 }, add, add)
+		assert.Empty(t, got)
+	})
+
+	assert.Panics(t, func() {
+		seq.ParallelAggregateGroupedChunked(seq.Empty2[string, int](), 1, 0, func(string) int { return 0 }, add, add)
+	})
+}
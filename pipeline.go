@@ -0,0 +1,327 @@
+package seq
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// Pipeline is a set of concurrent stages connected by channels.
+//
+// A pipeline starts with one or more sources, created with [PipelineSource], whose values flow
+// through stages created with [PipelineStage] and [PipelineThrough] into sinks created with
+// [PipelineSink]. Nothing runs until [Pipeline.Run] is called.
+type Pipeline struct {
+	stages     []pipelineStage
+	unconsumed int
+	ran        bool
+}
+
+// pipelineStage is a stage of a [Pipeline].
+type pipelineStage struct {
+	name        string
+	concurrency int
+
+	// work runs one of the goroutines of the stage
+	work func(ctx context.Context) error
+
+	// finish is called once every goroutine of the stage has returned
+	finish func()
+}
+
+// NewPipeline creates an empty pipeline.
+func NewPipeline() *Pipeline {
+	return &Pipeline{}
+}
+
+// Flow is the output of a pipeline stage, which is the input of another stage.
+//
+// Each flow must be consumed by exactly one stage.
+type Flow[V any] struct {
+	p        *Pipeline
+	ch       chan V
+	consumed bool
+
+	// done is closed when the stage that consumes the flow returns
+	done chan struct{}
+}
+
+// StageOptions configures a pipeline stage.
+//
+// The zero value runs a stage in a single goroutine with an unbuffered output.
+type StageOptions struct {
+	// Concurrency is the number of goroutines that run the stage; values are not kept in order
+	// when it is greater than 1. It is ignored for sources, which are iterated only once.
+	Concurrency int
+
+	// Buffer is the buffer size of the output of the stage. It is ignored for sinks.
+	Buffer int
+}
+
+// check panics if the options are invalid.
+func (opts StageOptions) check() {
+	if opts.Concurrency < 0 {
+		panic("seq.Pipeline: concurrency must be non-negative")
+	}
+
+	if opts.Buffer < 0 {
+		panic("seq.Pipeline: buffer must be non-negative")
+	}
+}
+
+// StageError is the error returned by [Pipeline.Run] when a stage fails.
+type StageError struct {
+	Stage string
+	Err   error
+}
+
+// Error returns the error message, prefixed with the name of the stage.
+func (e *StageError) Error() string {
+	return "seq.Pipeline: stage " + e.Stage + ": " + e.Err.Error()
+}
+
+// Unwrap returns the error of the stage.
+func (e *StageError) Unwrap() error {
+	return e.Err
+}
+
+// PipelineSource adds a stage that yields the values of a sequence to a pipeline.
+//
+// The stage fails with the first non-nil error of the sequence. The sequence is stopped early
+// if the stage that consumes its values returns before reading all of them.
+// This panics if the options are negative.
+func PipelineSource[V any](p *Pipeline, name string, opts StageOptions, seq iter.Seq2[V, error]) *Flow[V] {
+	opts.check()
+	out := newFlow[V](p, opts.Buffer)
+
+	p.add(name, 1, func(ctx context.Context) error {
+		for v, err := range seq {
+			if err != nil {
+				return err
+			}
+
+			if !out.send(ctx, v) {
+				break
+			}
+		}
+
+		return ctx.Err()
+	}, out.close)
+
+	return out
+}
+
+// PipelineStage adds a stage that applies a function to each value of a flow.
+//
+// The stage fails with the first non-nil error returned by the function.
+// This panics if the flow already has a consumer, or if the options are negative.
+func PipelineStage[V, VOut any](
+	in *Flow[V],
+	name string,
+	opts StageOptions,
+	f func(context.Context, V) (VOut, error),
+) *Flow[VOut] {
+	opts.check()
+	in.consume()
+	out := newFlow[VOut](in.p, opts.Buffer)
+
+	in.p.add(name, opts.Concurrency, func(ctx context.Context) error {
+		for v := range yieldChanContext(ctx, in.ch) {
+			r, err := f(ctx, v)
+			if err != nil {
+				return err
+			}
+
+			if !out.send(ctx, r) {
+				break
+			}
+		}
+
+		return ctx.Err()
+	}, func() {
+		in.stop()
+		out.close()
+	})
+
+	return out
+}
+
+// PipelineThrough adds a stage that transforms the values of a flow using a sequence function,
+// such as one that calls [Select], [Where] or [Chunk].
+//
+// Each goroutine of the stage calls the function once with a sequence of the values it receives,
+// so functions that combine values, such as [Chunk], only combine values within a goroutine.
+// This panics if the flow already has a consumer, or if the options are negative.
+func PipelineThrough[V, VOut any](
+	in *Flow[V],
+	name string,
+	opts StageOptions,
+	f func(iter.Seq[V]) iter.Seq[VOut],
+) *Flow[VOut] {
+	opts.check()
+	in.consume()
+	out := newFlow[VOut](in.p, opts.Buffer)
+
+	in.p.add(name, opts.Concurrency, func(ctx context.Context) error {
+		for v := range f(yieldChanContext(ctx, in.ch)) {
+			if !out.send(ctx, v) {
+				break
+			}
+		}
+
+		return ctx.Err()
+	}, func() {
+		in.stop()
+		out.close()
+	})
+
+	return out
+}
+
+// PipelineSink adds a stage that calls a function with each value of a flow.
+//
+// The stage fails with the first non-nil error returned by the function.
+// This panics if the flow already has a consumer, or if the options are negative.
+func PipelineSink[V any](in *Flow[V], name string, opts StageOptions, f func(context.Context, V) error) {
+	opts.check()
+	in.consume()
+
+	in.p.add(name, opts.Concurrency, func(ctx context.Context) error {
+		for v := range yieldChanContext(ctx, in.ch) {
+			if err := f(ctx, v); err != nil {
+				return err
+			}
+		}
+
+		return ctx.Err()
+	}, in.stop)
+}
+
+// Run runs every stage of the pipeline and waits for them to return.
+//
+// If a stage fails, the context passed to every stage is cancelled and Run returns a
+// [*StageError] with the first error. If the given context is done before the pipeline
+// completes, Run returns its error. If a stage panics, the other stages are cancelled and
+// the panic is raised again by Run.
+// This panics if Run was already called, or if a flow has no consumer.
+func (p *Pipeline) Run(ctx context.Context) error {
+	if p.ran {
+		panic("seq.Pipeline: Run called more than once")
+	}
+
+	if p.unconsumed > 0 {
+		panic("seq.Pipeline: every flow must have a consumer")
+	}
+
+	p.ran = true
+
+	stageCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var mu sync.Mutex
+	var firstErr error
+	interrupted := false
+
+	fail := func(name string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if stageCtx.Err() != nil {
+			// the stage stopped because the pipeline was cancelled
+			interrupted = true
+			return
+		}
+
+		firstErr = &StageError{Stage: name, Err: err}
+		cancel()
+	}
+
+	var panicked goroutinePanic
+	var wg sync.WaitGroup
+
+	for _, s := range p.stages {
+		var stageWG sync.WaitGroup
+
+		for range s.concurrency {
+			// stop the other stages if this one panics
+			panicked.goCancel(&stageWG, cancel, func() {
+				if err := s.work(stageCtx); err != nil {
+					fail(s.name, err)
+				}
+			})
+		}
+
+		wg.Go(func() {
+			stageWG.Wait()
+
+			s.finish()
+		})
+	}
+
+	wg.Wait()
+	panicked.repanic()
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	if interrupted {
+		return ctx.Err()
+	}
+
+	return nil
+}
+
+// add adds a stage to the pipeline.
+func (p *Pipeline) add(name string, concurrency int, work func(context.Context) error, finish func()) {
+	if p.ran {
+		panic("seq.Pipeline: stages cannot be added after Run")
+	}
+
+	p.stages = append(p.stages, pipelineStage{
+		name:        name,
+		concurrency: max(1, concurrency),
+		work:        work,
+		finish:      finish,
+	})
+}
+
+// newFlow creates the output of a stage.
+func newFlow[V any](p *Pipeline, buf int) *Flow[V] {
+	p.unconsumed++
+
+	return &Flow[V]{p: p, ch: make(chan V, buf), done: make(chan struct{})}
+}
+
+// consume marks the flow as consumed by a stage.
+func (f *Flow[V]) consume() {
+	if f.consumed {
+		panic("seq.Pipeline: flow already has a consumer")
+	}
+
+	f.consumed = true
+	f.p.unconsumed--
+}
+
+// send sends a value to the stage that consumes the flow.
+// Returns false if the context is done or the consuming stage has returned.
+func (f *Flow[V]) send(ctx context.Context, v V) bool {
+	select {
+	case f.ch <- v:
+		return true
+	case <-f.done:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// close closes the flow once the stage that produces it has returned.
+func (f *Flow[V]) close() {
+	close(f.ch)
+}
+
+// stop stops the stage that produces the flow once the stage that consumes it has returned.
+func (f *Flow[V]) stop() {
+	close(f.done)
+}
@@ -0,0 +1,210 @@
+package seq_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/arielsrv/go-seq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withNilErrors returns a sequence of values paired with nil errors.
+func withNilErrors[V any](vals iter.Seq[V]) iter.Seq2[V, error] {
+	return seq.Zip(vals, seq.RepeatForever[error](nil))
+}
+
+func Test_Pipeline(t *testing.T) {
+	t.Run("read, parse, enrich, batch and write", func(t *testing.T) {
+		p := seq.NewPipeline()
+
+		lines := seq.PipelineSource(p, "read", seq.StageOptions{Buffer: 4},
+			withNilErrors(seq.Select(seq.Take(naturals(), 100), strconv.Itoa)))
+
+		parsed := seq.PipelineStage(lines, "parse", seq.StageOptions{Concurrency: 4, Buffer: 4},
+			func(_ context.Context, line string) (int, error) { return strconv.Atoi(line) })
+
+		enriched := seq.PipelineThrough(parsed, "enrich", seq.StageOptions{Concurrency: 2},
+			func(s iter.Seq[int]) iter.Seq[int] {
+				return seq.Select(seq.Where(s, isEven), func(v int) int { return v * 10 })
+			})
+
+		batches := seq.PipelineThrough(enriched, "batch", seq.StageOptions{},
+			func(s iter.Seq[int]) iter.Seq[[]int] { return seq.Chunk(s, 8) })
+
+		var mu sync.Mutex
+		var written []int
+
+		seq.PipelineSink(batches, "write", seq.StageOptions{Concurrency: 2}, func(_ context.Context, batch []int) error {
+			assert.LessOrEqual(t, len(batch), 8)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			written = append(written, batch...)
+
+			return nil
+		})
+
+		require.NoError(t, p.Run(context.Background()))
+
+		want := seq.ToSlice(seq.Select(seq.Where(seq.Take(naturals(), 100), isEven), func(v int) int { return v * 10 }))
+		assert.ElementsMatch(t, want, written)
+	})
+
+	t.Run("keeps order without concurrency", func(t *testing.T) {
+		p := seq.NewPipeline()
+
+		source := seq.PipelineSource(p, "source", seq.StageOptions{}, withNilErrors(seq.Yield(1, 2, 3, 4)))
+		squared := seq.PipelineStage(source, "square", seq.StageOptions{Buffer: 2},
+			func(_ context.Context, v int) (int, error) { return v * v, nil })
+
+		var got []int
+		seq.PipelineSink(squared, "collect", seq.StageOptions{}, func(_ context.Context, v int) error {
+			got = append(got, v)
+			return nil
+		})
+
+		require.NoError(t, p.Run(context.Background()))
+		assert.Equal(t, []int{1, 4, 9, 16}, got)
+	})
+
+	t.Run("stage error cancels the pipeline", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		source, stopped := stoppable()
+
+		p := seq.NewPipeline()
+
+		vals := seq.PipelineSource(p, "source", seq.StageOptions{}, withNilErrors(source))
+		checked := seq.PipelineStage(vals, "check", seq.StageOptions{Concurrency: 3},
+			func(_ context.Context, v int) (int, error) {
+				if v == 50 {
+					return 0, errBoom
+				}
+
+				return v, nil
+			})
+
+		seq.PipelineSink(checked, "discard", seq.StageOptions{}, func(context.Context, int) error {
+			return nil
+		})
+
+		err := p.Run(context.Background())
+		require.ErrorIs(t, err, errBoom)
+
+		var stageErr *seq.StageError
+		require.ErrorAs(t, err, &stageErr)
+		assert.Equal(t, "check", stageErr.Stage)
+		assert.Equal(t, "seq.Pipeline: stage check: boom", err.Error())
+
+		<-stopped
+	})
+
+	t.Run("source error", func(t *testing.T) {
+		errRead := errors.New("read failed")
+		source := seq.Zip(seq.Yield(1, 2, 3), seq.Yield(nil, nil, errRead))
+
+		p := seq.NewPipeline()
+		vals := seq.PipelineSource(p, "read", seq.StageOptions{}, source)
+		seq.PipelineSink(vals, "discard", seq.StageOptions{}, func(context.Context, int) error { return nil })
+
+		err := p.Run(context.Background())
+		require.ErrorIs(t, err, errRead)
+		assert.Equal(t, "seq.Pipeline: stage read: read failed", err.Error())
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		source, stopped := stoppable()
+
+		p := seq.NewPipeline()
+		vals := seq.PipelineSource(p, "source", seq.StageOptions{}, withNilErrors(source))
+		seq.PipelineSink(vals, "cancel", seq.StageOptions{}, func(_ context.Context, v int) error {
+			if v == 10 {
+				cancel()
+			}
+
+			return nil
+		})
+
+		require.ErrorIs(t, p.Run(ctx), context.Canceled)
+		<-stopped
+	})
+
+	t.Run("consumer stops early", func(t *testing.T) {
+		source, stopped := stoppable()
+
+		p := seq.NewPipeline()
+		vals := seq.PipelineSource(p, "source", seq.StageOptions{Buffer: 2}, withNilErrors(source))
+		first := seq.PipelineThrough(vals, "take", seq.StageOptions{},
+			func(s iter.Seq[int]) iter.Seq[int] { return seq.Take(s, 5) })
+
+		var got []int
+		seq.PipelineSink(first, "collect", seq.StageOptions{}, func(_ context.Context, v int) error {
+			got = append(got, v)
+			return nil
+		})
+
+		require.NoError(t, p.Run(context.Background()))
+		assert.Equal(t, []int{0, 1, 2, 3, 4}, got)
+		<-stopped
+	})
+
+	t.Run("panic is raised by Run", func(t *testing.T) {
+		p := seq.NewPipeline()
+		vals := seq.PipelineSource(p, "source", seq.StageOptions{}, withNilErrors(naturals()))
+		seq.PipelineSink(vals, "panic", seq.StageOptions{}, func(_ context.Context, v int) error {
+			if v == 3 {
+				panic("boom")
+			}
+
+			return nil
+		})
+
+		assert.PanicsWithValue(t, "boom", func() { _ = p.Run(context.Background()) })
+	})
+
+	t.Run("empty pipeline", func(t *testing.T) {
+		assert.NoError(t, seq.NewPipeline().Run(context.Background()))
+	})
+}
+
+func Test_Pipeline_Panics(t *testing.T) {
+	t.Run("flow without consumer", func(t *testing.T) {
+		p := seq.NewPipeline()
+		seq.PipelineSource(p, "source", seq.StageOptions{}, withNilErrors(seq.Yield(1)))
+
+		assert.PanicsWithValue(t, "seq.Pipeline: every flow must have a consumer", func() {
+			_ = p.Run(context.Background())
+		})
+	})
+
+	t.Run("flow with two consumers", func(t *testing.T) {
+		p := seq.NewPipeline()
+		vals := seq.PipelineSource(p, "source", seq.StageOptions{}, withNilErrors(seq.Yield(1)))
+		seq.PipelineSink(vals, "first", seq.StageOptions{}, func(context.Context, int) error { return nil })
+
+		assert.PanicsWithValue(t, "seq.Pipeline: flow already has a consumer", func() {
+			seq.PipelineSink(vals, "second", seq.StageOptions{}, func(context.Context, int) error { return nil })
+		})
+	})
+
+	t.Run("run twice", func(t *testing.T) {
+		p := seq.NewPipeline()
+		require.NoError(t, p.Run(context.Background()))
+
+		assert.Panics(t, func() { _ = p.Run(context.Background()) })
+	})
+
+	t.Run("negative options", func(t *testing.T) {
+		p := seq.NewPipeline()
+		source := withNilErrors(seq.Yield(1))
+
+		assert.Panics(t, func() { seq.PipelineSource(p, "source", seq.StageOptions{Buffer: -1}, source) })
+		assert.Panics(t, func() { seq.PipelineSource(p, "source", seq.StageOptions{Concurrency: -1}, source) })
+	})
+}
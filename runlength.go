@@ -0,0 +1,56 @@
+package seq
+
+import (
+	"iter"
+)
+
+// RunLengthEncode returns a sequence of runs of consecutive equal values from a sequence,
+// yielding each value with the number of times it was repeated.
+//
+// Use [RunLengthDecode] to restore the original sequence.
+func RunLengthEncode[V comparable](seq iter.Seq[V]) iter.Seq2[V, int] {
+	return RunLengthEncodeFunc(seq, func(a, b V) bool { return a == b })
+}
+
+// RunLengthEncodeFunc returns a sequence of runs of consecutive equal values from a sequence
+// using a function to compare values, yielding the first value of each run with the number of
+// values in the run.
+func RunLengthEncodeFunc[V any](seq iter.Seq[V], f func(V, V) bool) iter.Seq2[V, int] {
+	return func(yield func(V, int) bool) {
+		var run V
+		n := 0
+
+		for v := range seq {
+			if n > 0 && f(run, v) {
+				n++
+				continue
+			}
+
+			if n > 0 && !yield(run, n) {
+				return
+			}
+
+			run, n = v, 1
+		}
+
+		if n > 0 {
+			yield(run, n)
+		}
+	}
+}
+
+// RunLengthDecode returns a sequence that repeats each value of a run-length encoded sequence
+// the given number of times.
+//
+// Values with a count that is not positive are skipped.
+func RunLengthDecode[V any](seq iter.Seq2[V, int]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for v, n := range seq {
+			for range n {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package seq_test
+
+import (
+	"iter"
+	"strings"
+	"testing"
+
+	"github.com/arielsrv/go-seq"
+	"github.com/arielsrv/go-seq/internal/seqtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RunLengthEncode(t *testing.T) {
+	tests := []struct {
+		name string
+		seq  iter.Seq[string]
+		want []seqtest.KeyValuePair[string, int]
+	}{
+		{
+			name: "repeated values",
+			seq:  seq.Yield("a", "a", "b", "c", "c", "c", "a"),
+			want: []seqtest.KeyValuePair[string, int]{
+				{Key: "a", Value: 2},
+				{Key: "b", Value: 1},
+				{Key: "c", Value: 3},
+				{Key: "a", Value: 1},
+			},
+		},
+		{
+			name: "zero value",
+			seq:  seq.Yield("", "", "a"),
+			want: []seqtest.KeyValuePair[string, int]{
+				{Key: "", Value: 2},
+				{Key: "a", Value: 1},
+			},
+		},
+		{
+			name: "single value",
+			seq:  seq.Yield("a"),
+			want: []seqtest.KeyValuePair[string, int]{
+				{Key: "a", Value: 1},
+			},
+		},
+		{
+			name: "empty sequence",
+			seq:  seq.Yield[string](),
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := seq.RunLengthEncode(tt.seq)
+			seqtest.AssertEqual2(t, tt.want, got)
+		})
+	}
+
+	t.Run("single-use sequence", func(t *testing.T) {
+		ch := make(chan int, 4)
+		ch <- 1
+		ch <- 1
+		ch <- 2
+		ch <- 2
+		close(ch)
+
+		seqtest.AssertEqual2(t, []seqtest.KeyValuePair[int, int]{{Key: 1, Value: 2}, {Key: 2, Value: 2}},
+			seq.RunLengthEncode(seq.YieldChan(ch)))
+	})
+
+	t.Run("infinite sequence", func(t *testing.T) {
+		thirds := seq.Select(naturals(), func(v int) int { return v / 3 })
+		got := seq.Values(seq.RunLengthEncode(thirds))
+
+		assert.Equal(t, []int{3, 3}, limitedCollector(got, 2))
+	})
+}
+
+func Test_RunLengthEncodeFunc(t *testing.T) {
+	got := seq.RunLengthEncodeFunc(seq.Yield("a", "A", "b", "B", "b"), strings.EqualFold)
+
+	seqtest.AssertEqual2(t, []seqtest.KeyValuePair[string, int]{
+		{Key: "a", Value: 2},
+		{Key: "b", Value: 3},
+	}, got)
+}
+
+func Test_RunLengthDecode(t *testing.T) {
+	tests := []struct {
+		name string
+		seq  iter.Seq2[string, int]
+		want []string
+	}{
+		{
+			name: "runs",
+			seq:  seq.Zip(seq.Yield("a", "b", "c"), seq.Yield(2, 1, 3)),
+			want: []string{"a", "a", "b", "c", "c", "c"},
+		},
+		{
+			name: "non-positive counts",
+			seq:  seq.Zip(seq.Yield("a", "b", "c"), seq.Yield(0, -1, 1)),
+			want: []string{"c"},
+		},
+		{
+			name: "empty sequence",
+			seq:  seq.Empty2[string, int](),
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := seq.RunLengthDecode(tt.seq)
+			seqtest.AssertEqual(t, tt.want, got)
+		})
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		vals := []int{1, 1, 1, 2, 3, 3, 1}
+		seqtest.AssertEqual(t, vals, seq.RunLengthDecode(seq.RunLengthEncode(seq.Yield(vals...))))
+	})
+
+	t.Run("early return", func(t *testing.T) {
+		got := seq.RunLengthDecode(seq.Zip(seq.Yield("a", "b"), seq.Yield(3, 3)))
+		assert.Equal(t, []string{"a", "a"}, limitedCollector(got, 2))
+	})
+}
@@ -0,0 +1,144 @@
+package seq
+
+import (
+	"container/heap"
+	"iter"
+	"math"
+	"math/rand/v2"
+)
+
+// maxReservoirPrealloc is the largest reservoir that is allocated before reading a sequence;
+// larger reservoirs grow as values are read.
+const maxReservoirPrealloc = 1024
+
+// Sample returns a uniform random sample of at most k values from a sequence.
+//
+// This uses reservoir sampling, so the sequence is iterated once and only k values are
+// kept in memory. Every value has the same probability of being selected.
+// The order of the sampled values is not specified.
+// This panics if k is negative.
+func Sample[V any](seq iter.Seq[V], k int, rng *rand.Rand) []V {
+	if k < 0 {
+		panic("seq.Sample: k must be non-negative")
+	}
+
+	reservoir := make([]V, 0, min(k, maxReservoirPrealloc))
+	i := 0
+
+	for v := range seq {
+		i++
+
+		if len(reservoir) < k {
+			reservoir = append(reservoir, v)
+			continue
+		}
+
+		// replace a random value with probability k/i
+		if j := rng.IntN(i); j < k {
+			reservoir[j] = v
+		}
+	}
+
+	return reservoir
+}
+
+// SampleWeighted returns a weighted random sample of at most k values from a sequence.
+//
+// The probability of a value being selected is proportional to its weight.
+// Values with a non-positive weight are never selected.
+// This uses the A-Res reservoir algorithm, so the sequence is iterated once and only
+// k values are kept in memory. The order of the sampled values is not specified.
+// This panics if k is negative.
+func SampleWeighted[V any](seq iter.Seq[V], k int, weight func(V) float64, rng *rand.Rand) []V {
+	if k < 0 {
+		panic("seq.SampleWeighted: k must be non-negative")
+	}
+
+	if k == 0 {
+		return make([]V, 0)
+	}
+
+	reservoir := make(weightedReservoir[V], 0, min(k, maxReservoirPrealloc))
+
+	for v := range seq {
+		w := weight(v)
+		if !(w > 0) {
+			continue
+		}
+
+		// the key u^(1/w) is compared in log space to avoid underflow for small weights
+		key := math.Log(1-rng.Float64()) / w
+
+		switch {
+		case len(reservoir) < k:
+			heap.Push(&reservoir, weightedValue[V]{val: v, key: key})
+
+		case key > reservoir[0].key:
+			reservoir[0] = weightedValue[V]{val: v, key: key}
+			heap.Fix(&reservoir, 0)
+		}
+	}
+
+	sample := make([]V, len(reservoir))
+	for i, wv := range reservoir {
+		sample[i] = wv.val
+	}
+
+	return sample
+}
+
+// weightedValue is a value with its random sampling key.
+type weightedValue[V any] struct {
+	val V
+	key float64
+}
+
+// weightedReservoir is a min-heap of weighted values ordered by key.
+type weightedReservoir[V any] []weightedValue[V]
+
+func (r weightedReservoir[V]) Len() int           { return len(r) }
+func (r weightedReservoir[V]) Less(i, j int) bool { return r[i].key < r[j].key }
+func (r weightedReservoir[V]) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
+
+func (r *weightedReservoir[V]) Push(x any) {
+	*r = append(*r, x.(weightedValue[V]))
+}
+
+func (r *weightedReservoir[V]) Pop() any {
+	old := *r
+	last := old[len(old)-1]
+	*r = old[:len(old)-1]
+
+	return last
+}
+
+// SampleRate returns a sequence that includes each value from a sequence independently
+// with probability p.
+//
+// The sampling is lazy, so this can be used with infinite sequences.
+// This panics if p is not between 0 and 1.
+func SampleRate[V any](seq iter.Seq[V], p float64, rng *rand.Rand) iter.Seq[V] {
+	if !(p >= 0 && p <= 1) {
+		panic("seq.SampleRate: p must be between 0 and 1")
+	}
+
+	return func(yield func(V) bool) {
+		for v := range seq {
+			if rng.Float64() < p {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Shuffled collects values from a sequence into a new slice and then shuffles it.
+func Shuffled[V any](seq iter.Seq[V], rng *rand.Rand) []V {
+	s := ToSlice(seq)
+	rng.Shuffle(len(s), func(i, j int) {
+		s[i], s[j] = s[j], s[i]
+	})
+
+	return s
+}
@@ -0,0 +1,143 @@
+package seq_test
+
+import (
+	"iter"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/arielsrv/go-seq"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRand(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed))
+}
+
+// naturals returns an infinite sequence of 0, 1, 2, ...
+func naturals() iter.Seq[int] {
+	return seq.Iterate(0, func(i int) int { return i + 1 })
+}
+
+func Test_Sample(t *testing.T) {
+	tests := []struct {
+		name    string
+		vals    []int
+		k       int
+		wantLen int
+	}{
+		{name: "fewer values than k", vals: []int{1, 2, 3}, k: 5, wantLen: 3},
+		{name: "more values than k", vals: []int{1, 2, 3, 4, 5, 6, 7, 8}, k: 3, wantLen: 3},
+		{name: "zero", vals: []int{1, 2, 3}, k: 0, wantLen: 0},
+		{name: "empty", vals: nil, k: 3, wantLen: 0},
+		{name: "huge k", vals: []int{1, 2, 3}, k: math.MaxInt, wantLen: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := seq.Sample(seq.Yield(tt.vals...), tt.k, newRand(1))
+			assert.Len(t, got, tt.wantLen)
+			assert.Subset(t, tt.vals, got)
+			assert.Len(t, seq.ToSlice(seq.Distinct(seq.Yield(got...))), tt.wantLen)
+		})
+	}
+
+	assert.Panics(t, func() { seq.Sample(seq.Yield(1), -1, newRand(1)) })
+}
+
+func Test_Sample_Reproducible(t *testing.T) {
+	vals := seq.Yield(seq.ToSlice(seq.Take(naturals(), 1000))...)
+
+	assert.Equal(t, seq.Sample(vals, 10, newRand(42)), seq.Sample(vals, 10, newRand(42)))
+}
+
+func Test_Sample_Uniform(t *testing.T) {
+	rng := newRand(7)
+	counts := make([]int, 10)
+
+	for range 10000 {
+		for _, v := range seq.Sample(seq.Yield(0, 1, 2, 3, 4, 5, 6, 7, 8, 9), 3, rng) {
+			counts[v]++
+		}
+	}
+
+	// each value is expected to be selected 3000 times
+	for v, count := range counts {
+		assert.InDelta(t, 3000, count, 200, "value %d", v)
+	}
+}
+
+func Test_SampleWeighted(t *testing.T) {
+	weight := func(v int) float64 { return float64(v) }
+
+	t.Run("heavier values are more likely", func(t *testing.T) {
+		rng := newRand(3)
+		counts := make(map[int]int)
+
+		for range 10000 {
+			for _, v := range seq.SampleWeighted(seq.Yield(1, 2, 7), 1, weight, rng) {
+				counts[v]++
+			}
+		}
+
+		assert.InDelta(t, 1000, counts[1], 150)
+		assert.InDelta(t, 2000, counts[2], 200)
+		assert.InDelta(t, 7000, counts[7], 200)
+	})
+
+	t.Run("non-positive weights are never selected", func(t *testing.T) {
+		got := seq.SampleWeighted(seq.Yield(-1, 0, 3, 4), 4, weight, newRand(1))
+		assert.ElementsMatch(t, []int{3, 4}, got)
+	})
+
+	t.Run("zero", func(t *testing.T) {
+		assert.Empty(t, seq.SampleWeighted(seq.Yield(1, 2), 0, weight, newRand(1)))
+	})
+
+	t.Run("huge k", func(t *testing.T) {
+		got := seq.SampleWeighted(seq.Yield(1, 2), math.MaxInt, weight, newRand(1))
+		assert.ElementsMatch(t, []int{1, 2}, got)
+	})
+
+	assert.Panics(t, func() { seq.SampleWeighted(seq.Yield(1), -1, weight, newRand(1)) })
+}
+
+func Test_SampleRate(t *testing.T) {
+	tests := []struct {
+		name string
+		p    float64
+		want int
+	}{
+		{name: "none", p: 0, want: 0},
+		{name: "all", p: 1, want: 10000},
+		{name: "quarter", p: 0.25, want: 2500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vals := seq.Repeat(1, 10000)
+			got := seq.Count(seq.SampleRate(vals, tt.p, newRand(5)))
+			assert.InDelta(t, tt.want, got, 150)
+		})
+	}
+
+	t.Run("lazy", func(t *testing.T) {
+		got := seq.ToSlice(seq.Take(seq.SampleRate(naturals(), 0.5, newRand(5)), 5))
+		assert.Len(t, got, 5)
+		assert.True(t, slices.IsSorted(got))
+	})
+
+	assert.Panics(t, func() { seq.SampleRate(seq.Yield(1), 1.5, newRand(1)) })
+}
+
+func Test_Shuffled(t *testing.T) {
+	vals := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	got := seq.Shuffled(seq.Yield(vals...), newRand(9))
+	assert.ElementsMatch(t, vals, got)
+	assert.NotEqual(t, vals, got)
+	assert.Equal(t, got, seq.Shuffled(seq.Yield(vals...), newRand(9)))
+
+	assert.Empty(t, seq.Shuffled(seq.Empty[int](), newRand(9)))
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"iter"
 	"maps"
+	"slices"
 
 	"golang.org/x/exp/constraints"
 )
@@ -115,6 +116,36 @@ func Chunk[V any](seq iter.Seq[V], size int) iter.Seq[[]V] {
 	}
 }
 
+// ChunkBy splits the values of a sequence into runs of consecutive values with equal keys,
+// yielding each run with its key.
+//
+// Values with equal keys that are not consecutive are yielded in separate runs;
+// use [Grouped] with [SelectKeys] to group them regardless of their order.
+func ChunkBy[V any, K comparable](seq iter.Seq[V], f func(V) K) iter.Seq2[K, []V] {
+	return func(yield func(K, []V) bool) {
+		var key K
+		var chunk []V
+
+		for v := range seq {
+			k := f(v)
+			if len(chunk) > 0 && k != key {
+				if !yield(key, chunk) {
+					return
+				}
+
+				chunk = nil
+			}
+
+			key = k
+			chunk = append(chunk, v)
+		}
+
+		if len(chunk) > 0 {
+			yield(key, chunk)
+		}
+	}
+}
+
 // Concat concatenates multiples sequences into a single sequence.
 func Concat[V any](seqs ...iter.Seq[V]) iter.Seq[V] {
 	return func(yield func(V) bool) {
@@ -172,6 +203,37 @@ func CountFunc[V any](seq iter.Seq[V], f func(V) bool) int {
 	return count
 }
 
+// Cycle returns an infinite sequence that repeats the values of a sequence.
+//
+// The values are cached during the first pass, so the given sequence is only iterated once.
+// This allows single-use sequences, such as those from [YieldChan], to be repeated.
+// The resulting sequence is empty if the given sequence is empty.
+// Use [Take] or [TakeWhile] to limit the resulting sequence.
+func Cycle[V any](seq iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		var cache []V
+
+		for v := range seq {
+			cache = append(cache, v)
+			if !yield(v) {
+				return
+			}
+		}
+
+		if len(cache) == 0 {
+			return
+		}
+
+		for {
+			for _, v := range cache {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
 // Empty returns an empty sequence.
 func Empty[V any]() iter.Seq[V] {
 	return func(func(V) bool) {}
@@ -252,6 +314,71 @@ func FirstFunc[V any](seq iter.Seq[V], f func(V) bool) (V, bool) {
 	return zero, false
 }
 
+// Generate returns an infinite sequence of values returned by a function.
+//
+// Use [Take] or [TakeWhile] to limit the resulting sequence.
+func Generate[V any](f func() V) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for {
+			if !yield(f()) {
+				return
+			}
+		}
+	}
+}
+
+// Interleave returns a sequence that takes one value from each of the given sequences in turn,
+// until all of them are exhausted.
+//
+// Sequences that are exhausted are skipped, so the remaining values of longer sequences are
+// yielded at the end. Use [RoundRobin] to take more than one value from each sequence in turn.
+func Interleave[V any](seqs ...iter.Seq[V]) iter.Seq[V] {
+	weights := make([]int, len(seqs))
+	for i := range weights {
+		weights[i] = 1
+	}
+
+	return roundRobin(seqs, weights)
+}
+
+// Intersperse returns a sequence that yields a separator between each of the values of a sequence.
+func Intersperse[V any](seq iter.Seq[V], sep V) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		first := true
+
+		for v := range seq {
+			if !first && !yield(sep) {
+				return
+			}
+
+			first = false
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Iterate returns an infinite sequence of a seed value followed by repeated applications of
+// a function: seed, f(seed), f(f(seed)), ...
+//
+// Use [Take] or [TakeWhile] to limit the resulting sequence.
+//
+// Example:
+//
+//	// yields (1), (2), (4), (8), (16)
+//	powers := seq.Take(seq.Iterate(1, func(v int) int { return v * 2 }), 5)
+func Iterate[V any](seed V, f func(V) V) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for v := seed; ; v = f(v) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
 // Last returns the last value of a sequence.
 //
 // A second return value indicates whether the sequence contained any values.
@@ -408,6 +535,20 @@ func OfType[V, VOut any](seq iter.Seq[V]) iter.Seq[VOut] {
 	}
 }
 
+// PartitionSeq splits a sequence into a sequence of the values that satisfy a condition and
+// a sequence of the values that do not.
+//
+// This is a lazy version of [Partition]. The given sequence is iterated only once, and
+// values are buffered until they have been read by both returned sequences, as with [Tee].
+// Each returned sequence can be iterated only once.
+func PartitionSeq[V any](seq iter.Seq[V], f func(V) bool) (matching, rest iter.Seq[V]) {
+	seqs := Tee(seq, 2)
+	matching = Where(seqs[0], f)
+	rest = Where(seqs[1], func(v V) bool { return !f(v) })
+
+	return matching, rest
+}
+
 // Prepend adds values to the beginning of a sequence.
 func Prepend[V any](seq iter.Seq[V], vals ...V) iter.Seq[V] {
 	return func(yield func(V) bool) {
@@ -474,6 +615,127 @@ func Repeat[V any](val V, n int) iter.Seq[V] {
 	}
 }
 
+// RepeatForever returns an infinite sequence that yields a given value.
+//
+// Use [Take] or [TakeWhile] to limit the resulting sequence.
+func RepeatForever[V any](val V) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for {
+			if !yield(val) {
+				return
+			}
+		}
+	}
+}
+
+// RoundRobin returns a sequence that takes up to a given number of values from each of the
+// given sequences in turn, until all of them are exhausted.
+//
+// The weight of each sequence is the number of values taken from it per turn, so sequences with
+// higher weights get a larger share of the output while every sequence keeps making progress.
+// This panics if the number of weights does not match the number of sequences,
+// or if any weight is not positive.
+func RoundRobin[V any](weights []int, seqs ...iter.Seq[V]) iter.Seq[V] {
+	if len(weights) != len(seqs) {
+		panic("seq.RoundRobin: number of weights must match number of sequences")
+	}
+
+	for _, w := range weights {
+		if w <= 0 {
+			panic("seq.RoundRobin: weights must be positive")
+		}
+	}
+
+	return roundRobin(seqs, slices.Clone(weights))
+}
+
+// roundRobin implements [Interleave] and [RoundRobin].
+func roundRobin[V any](seqs []iter.Seq[V], weights []int) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		nexts := make([]func() (V, bool), len(seqs))
+
+		for i, seq := range seqs {
+			next, stop := iter.Pull(seq)
+			defer stop()
+
+			nexts[i] = next
+		}
+
+		for active := len(nexts); active > 0; {
+			for i, next := range nexts {
+				if next == nil {
+					continue
+				}
+
+				for range weights[i] {
+					v, ok := next()
+					if !ok {
+						nexts[i] = nil
+						active--
+
+						break
+					}
+
+					if !yield(v) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// RunningMax returns a sequence of the maximum value seen so far at each value of a sequence.
+func RunningMax[V cmp.Ordered](seq iter.Seq[V]) iter.Seq[V] {
+	return runningFunc(seq, func(maxVal, v V) V { return max(maxVal, v) })
+}
+
+// RunningMin returns a sequence of the minimum value seen so far at each value of a sequence.
+func RunningMin[V cmp.Ordered](seq iter.Seq[V]) iter.Seq[V] {
+	return runningFunc(seq, func(minVal, v V) V { return min(minVal, v) })
+}
+
+// RunningSum returns a sequence of the sum of the values seen so far at each value of a sequence.
+func RunningSum[V constraints.Integer | constraints.Float](seq iter.Seq[V]) iter.Seq[V] {
+	return Scan(seq, 0, func(sum, v V) V { return sum + v })
+}
+
+// runningFunc is like [Scan], but uses the first value of the sequence as the initial accumulator.
+func runningFunc[V any](seq iter.Seq[V], f func(V, V) V) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		var acc V
+		first := true
+
+		for v := range seq {
+			if first {
+				acc, first = v, false
+			} else {
+				acc = f(acc, v)
+			}
+
+			if !yield(acc) {
+				return
+			}
+		}
+	}
+}
+
+// Scan applies an accumulator function over a sequence and yields every intermediate result.
+//
+// This is like [Aggregate], but lazy: the accumulator is yielded after each value is added,
+// so the returned sequence has as many values as the given one. The initial value is not yielded.
+func Scan[V, A any](seq iter.Seq[V], init A, f func(A, V) A) iter.Seq[A] {
+	return func(yield func(A) bool) {
+		acc := init
+		for v := range seq {
+			acc = f(acc, v)
+			if !yield(acc) {
+				return
+			}
+		}
+	}
+}
+
 // Select projects each value of a sequence into a new value.
 func Select[V, VOut any](seq iter.Seq[V], f func(V) VOut) iter.Seq[VOut] {
 	return func(yield func(VOut) bool) {
@@ -594,6 +856,180 @@ func SkipWhile[V any](seq iter.Seq[V], f func(int, V) bool) iter.Seq[V] {
 	}
 }
 
+// SplitAt splits a sequence into a sequence of its first n values and a sequence of the rest.
+//
+// The given sequence is iterated only once, so this works with single-use sequences, such as
+// those from [YieldChan]. If the tail is read before the head, the first n values are buffered
+// until the head reads them or stops; no other values are buffered. The given sequence is
+// stopped once both returned sequences have stopped.
+// Each returned sequence can be iterated only once, unless n is not positive, in which case the
+// head is empty and the tail is the given sequence.
+func SplitAt[V any](seq iter.Seq[V], n int) (head, tail iter.Seq[V]) {
+	if n <= 0 {
+		return Empty[V](), seq
+	}
+
+	s := &split[V]{seq: seq, n: n}
+
+	return s.head, s.tail
+}
+
+// split holds the shared state of the sequences returned by [SplitAt].
+type split[V any] struct {
+	seq  iter.Seq[V]
+	next func() (V, bool)
+	stop func()
+	n    int
+
+	// buf holds the values read by the tail that the head has not read yet, which are always
+	// among the first n values
+	buf []V
+
+	// read is the number of values read from the sequence
+	read int
+
+	headDone bool
+	tailDone bool
+	done     bool
+}
+
+// head yields the first n values.
+func (s *split[V]) head(yield func(V) bool) {
+	if s.headDone {
+		return
+	}
+
+	defer func() {
+		s.headDone = true
+		s.buf = nil
+		s.detach()
+	}()
+
+	for range s.n {
+		var v V
+
+		if len(s.buf) > 0 {
+			v = s.buf[0]
+			s.buf = s.buf[1:]
+		} else {
+			var ok bool
+			if v, ok = s.pull(); !ok {
+				return
+			}
+		}
+
+		if !yield(v) {
+			return
+		}
+	}
+}
+
+// tail yields the values after the first n.
+func (s *split[V]) tail(yield func(V) bool) {
+	if s.tailDone {
+		return
+	}
+
+	defer func() {
+		s.tailDone = true
+		s.detach()
+	}()
+
+	for {
+		v, ok := s.pull()
+		if !ok {
+			return
+		}
+
+		if s.read <= s.n {
+			// keep the value for the head, unless it has stopped
+			if !s.headDone {
+				s.buf = append(s.buf, v)
+			}
+
+			continue
+		}
+
+		if !yield(v) {
+			return
+		}
+	}
+}
+
+// pull reads the next value from the sequence.
+func (s *split[V]) pull() (V, bool) {
+	var zero V
+	if s.done {
+		return zero, false
+	}
+
+	if s.next == nil {
+		s.next, s.stop = iter.Pull(s.seq)
+	}
+
+	v, ok := s.next()
+	if !ok {
+		s.done = true
+		return zero, false
+	}
+
+	s.read++
+
+	return v, true
+}
+
+// detach stops the sequence if both returned sequences have stopped.
+func (s *split[V]) detach() {
+	if !s.headDone || !s.tailDone {
+		return
+	}
+
+	if s.stop != nil && !s.done {
+		s.stop()
+	}
+
+	s.done = true
+}
+
+// SplitOn splits the values of a sequence into slices separated by a given value.
+//
+// This works like [SplitWhen] with a condition that matches the separator.
+func SplitOn[V comparable](seq iter.Seq[V], sep V) iter.Seq[[]V] {
+	return SplitWhen(seq, func(v V) bool { return v == sep })
+}
+
+// SplitWhen splits the values of a sequence into slices separated by the values that satisfy
+// a condition.
+//
+// The separators are not included in the slices. As with [strings.Split], consecutive
+// separators and separators at either end yield empty slices; an empty sequence yields nothing.
+func SplitWhen[V any](seq iter.Seq[V], f func(V) bool) iter.Seq[[]V] {
+	return func(yield func([]V) bool) {
+		var chunk []V
+		started := false
+
+		for v := range seq {
+			started = true
+
+			if f(v) {
+				if !yield(chunk) {
+					return
+				}
+
+				chunk = nil
+
+				continue
+			}
+
+			chunk = append(chunk, v)
+		}
+
+		if started {
+			yield(chunk)
+		}
+	}
+}
+
 // Sum computes the sum of the values in a sequence.
 func Sum[V constraints.Integer | constraints.Float](seq iter.Seq[V]) V {
 	var sum V
@@ -607,17 +1043,22 @@ func Sum[V constraints.Integer | constraints.Float](seq iter.Seq[V]) V {
 // Take returns a given number of values from the start of a sequence.
 func Take[V any](seq iter.Seq[V], n int) iter.Seq[V] {
 	return func(yield func(V) bool) {
+		if n <= 0 {
+			return
+		}
+
 		i := 0
 		for v := range seq {
-			if i >= n {
-				return
-			}
-
 			if !yield(v) {
 				return
 			}
 
+			// stop as soon as enough values were taken to avoid requesting another value,
+			// which matters for infinite or expensive sequences
 			i++
+			if i >= n {
+				return
+			}
 		}
 	}
 }
@@ -641,6 +1082,36 @@ func TakeWhile[V any](seq iter.Seq[V], f func(int, V) bool) iter.Seq[V] {
 	}
 }
 
+// Unfold returns a sequence generated from an initial state by a function that returns
+// the next value and the next state.
+//
+// The sequence ends when the function returns false.
+//
+// Example:
+//
+//	// yields (0), (1), (1), (2), (3), (5), (8)
+//	fib := seq.Unfold([2]int{0, 1}, func(s [2]int) (int, [2]int, bool) {
+//		return s[0], [2]int{s[1], s[0] + s[1]}, s[0] < 10
+//	})
+func Unfold[S, V any](state S, f func(S) (V, S, bool)) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		s := state
+
+		for {
+			v, next, ok := f(s)
+			if !ok {
+				return
+			}
+
+			if !yield(v) {
+				return
+			}
+
+			s = next
+		}
+	}
+}
+
 // Where filters a sequence based on a predicate.
 func Where[V any](seq iter.Seq[V], f func(V) bool) iter.Seq[V] {
 	return func(yield func(V) bool) {
@@ -44,6 +44,22 @@ func Keys[K, V any](seq iter.Seq2[K, V]) iter.Seq[K] {
 	}
 }
 
+// Scan2 applies an accumulator function over a key-value sequence and yields every intermediate
+// result with the key of the value that produced it.
+//
+// The initial value is not yielded.
+func Scan2[K, V, A any](seq iter.Seq2[K, V], init A, f func(A, K, V) A) iter.Seq2[K, A] {
+	return func(yield func(K, A) bool) {
+		acc := init
+		for k, v := range seq {
+			acc = f(acc, k, v)
+			if !yield(k, acc) {
+				return
+			}
+		}
+	}
+}
+
 // Select2 projects each key-value pair of a sequence into a new key-value pair.
 func Select2[K, V, KOut, VOut any](seq iter.Seq2[K, V], f func(K, V) (KOut, VOut)) iter.Seq2[KOut, VOut] {
 	return func(yield func(KOut, VOut) bool) {
@@ -96,6 +112,20 @@ func WithIndex[V any](seq iter.Seq[V]) iter.Seq2[int, V] {
 	}
 }
 
+// Unzip splits a key-value sequence into a sequence of keys and a sequence of values.
+//
+// The given sequence is iterated only once, so this works with single-use sequences.
+// Keys and values are buffered until they have been read by both returned sequences,
+// as with [Tee]. Each returned sequence can be iterated only once.
+// Use [Keys] and [Values] instead if the sequence can be iterated more than once.
+func Unzip[K, V any](seq iter.Seq2[K, V]) (iter.Seq[K], iter.Seq[V]) {
+	seqs := Tee(ToPairs(seq), 2)
+	keys := Select(seqs[0], func(p Pair[K, V]) K { return p.Key })
+	vals := Select(seqs[1], func(p Pair[K, V]) V { return p.Value })
+
+	return keys, vals
+}
+
 // Values returns the values from a key-value sequence.
 func Values[K, V any](seq iter.Seq2[K, V]) iter.Seq[V] {
 	return func(yield func(V) bool) {
@@ -150,3 +180,161 @@ func Zip[K, V any](keys iter.Seq[K], vals iter.Seq[V]) iter.Seq2[K, V] {
 		}
 	}
 }
+
+// Zip3 returns a sequence of tuples that combine values from three sequences.
+//
+// The resulting sequence will be as long as the shortest given sequence.
+func Zip3[A, B, C any](a iter.Seq[A], b iter.Seq[B], c iter.Seq[C]) iter.Seq[Triple[A, B, C]] {
+	return func(yield func(Triple[A, B, C]) bool) {
+		nextB, stopB := iter.Pull(b)
+		defer stopB()
+
+		nextC, stopC := iter.Pull(c)
+		defer stopC()
+
+		for va := range a {
+			vb, ok := nextB()
+			if !ok {
+				return
+			}
+
+			vc, ok := nextC()
+			if !ok {
+				return
+			}
+
+			if !yield(Triple[A, B, C]{First: va, Second: vb, Third: vc}) {
+				return
+			}
+		}
+	}
+}
+
+// ZipLongest returns a key-value sequence that combines values from two value sequences.
+// The first sequence is used as the key and the second sequence is used as the value.
+//
+// The resulting sequence will be as long as the longest given sequence.
+// Fill values are used in place of the missing values of the shorter sequence.
+//
+// Example:
+//
+//	seqK := seq.Yield(1, 2, 3)
+//	seqV := seq.Yield("a", "b")
+//
+//	// yields (1, "a"), (2, "b"), (3, "-")
+//	kvs := seq.ZipLongest(seqK, seqV, 0, "-")
+func ZipLongest[K, V any](keys iter.Seq[K], vals iter.Seq[V], fillK K, fillV V) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		zipLongest(keys, vals, func(k K, okK bool, v V, okV bool) bool {
+			if !okK {
+				k = fillK
+			}
+
+			if !okV {
+				v = fillV
+			}
+
+			return yield(k, v)
+		})
+	}
+}
+
+// ZipLongestFunc returns a sequence that combines values from two sequences with a function.
+//
+// The resulting sequence will be as long as the longest given sequence.
+// Boolean flags are provided to indicate whether each value is present; once the shorter
+// sequence ends, its values are the zero value and its flag is false.
+func ZipLongestFunc[A, B, VOut any](a iter.Seq[A], b iter.Seq[B], f func(A, bool, B, bool) VOut) iter.Seq[VOut] {
+	return func(yield func(VOut) bool) {
+		zipLongest(a, b, func(va A, okA bool, vb B, okB bool) bool {
+			return yield(f(va, okA, vb, okB))
+		})
+	}
+}
+
+// zipLongest calls yield with the values of two sequences until both sequences end.
+func zipLongest[A, B any](a iter.Seq[A], b iter.Seq[B], yield func(A, bool, B, bool) bool) {
+	nextB, stopB := iter.Pull(b)
+	defer stopB()
+
+	okB := true
+
+	for va := range a {
+		var vb B
+		if okB {
+			vb, okB = nextB()
+		}
+
+		if !yield(va, true, vb, okB) {
+			return
+		}
+	}
+
+	// drain the longer second sequence
+	var zero A
+	for okB {
+		var vb B
+		if vb, okB = nextB(); !okB {
+			return
+		}
+
+		if !yield(zero, false, vb, true) {
+			return
+		}
+	}
+}
+
+// ZipN returns a sequence of slices that combine values from multiple sequences.
+//
+// Each slice holds one value from each sequence, in the order of the sequences.
+// The resulting sequence will be as long as the shortest given sequence, and is empty
+// if no sequences are given.
+func ZipN[V any](seqs ...iter.Seq[V]) iter.Seq[[]V] {
+	return func(yield func([]V) bool) {
+		if len(seqs) == 0 {
+			return
+		}
+
+		nexts := make([]func() (V, bool), len(seqs))
+		for i, s := range seqs {
+			next, stop := iter.Pull(s)
+			defer stop()
+
+			nexts[i] = next
+		}
+
+		for {
+			vals := make([]V, len(nexts))
+			for i, next := range nexts {
+				v, ok := next()
+				if !ok {
+					return
+				}
+
+				vals[i] = v
+			}
+
+			if !yield(vals) {
+				return
+			}
+		}
+	}
+}
+
+// ZipWith returns a sequence that combines values from two sequences with a function.
+//
+// The resulting sequence will be as long as the shortest given sequence.
+//
+// Example:
+//
+//	// yields (5), (7), (9)
+//	sums := seq.ZipWith(seq.Yield(1, 2, 3), seq.Yield(4, 5, 6), func(a, b int) int { return a + b })
+func ZipWith[A, B, VOut any](a iter.Seq[A], b iter.Seq[B], f func(A, B) VOut) iter.Seq[VOut] {
+	return func(yield func(VOut) bool) {
+		for va, vb := range Zip(a, b) {
+			if !yield(f(va, vb)) {
+				return
+			}
+		}
+	}
+}
@@ -2,6 +2,7 @@ package seq_test
 
 import (
 	"iter"
+	"strconv"
 	"testing"
 
 	"github.com/arielsrv/go-seq"
@@ -152,6 +153,48 @@ func Test_Keys_EdgeCases(t *testing.T) {
 	})
 }
 
+func Test_Scan2(t *testing.T) {
+	tests := []struct {
+		name string
+		seq  iter.Seq2[string, int]
+		want []seqtest.KeyValuePair[string, int]
+	}{
+		{
+			name: "intermediate results",
+			seq:  seq.Zip(seq.Yield("a", "b", "c"), seq.Yield(1, 2, 3)),
+			want: []seqtest.KeyValuePair[string, int]{
+				{Key: "a", Value: 101},
+				{Key: "b", Value: 103},
+				{Key: "c", Value: 106},
+			},
+		},
+		{
+			name: "empty sequence",
+			seq:  seq.Empty2[string, int](),
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := seq.Scan2(tt.seq, 100, func(acc int, _ string, v int) int { return acc + v })
+			seqtest.AssertEqual2(t, tt.want, got)
+		})
+	}
+
+	t.Run("uses keys", func(t *testing.T) {
+		got := seq.Scan2(seq.WithIndex(seq.Yield("a", "b", "c")), "", func(acc string, i int, v string) string {
+			return acc + strconv.Itoa(i) + v
+		})
+
+		seqtest.AssertEqual2(t, []seqtest.KeyValuePair[int, string]{
+			{Key: 0, Value: "0a"},
+			{Key: 1, Value: "0a1b"},
+			{Key: 2, Value: "0a1b2c"},
+		}, got)
+	})
+}
+
 func Test_Select2(t *testing.T) {
 	tests := []struct {
 		name string
@@ -312,6 +355,35 @@ func Test_WithIndex(t *testing.T) {
 	}
 }
 
+func Test_Unzip(t *testing.T) {
+	t.Run("keys and values", func(t *testing.T) {
+		keys, vals := seq.Unzip(seq.Zip(seq.Yield("a", "b", "c"), seq.Yield(1, 2, 3)))
+
+		seqtest.AssertEqual(t, []string{"a", "b", "c"}, keys)
+		seqtest.AssertEqual(t, []int{1, 2, 3}, vals)
+	})
+
+	t.Run("single-use source", func(t *testing.T) {
+		ch := make(chan int, 3)
+		ch <- 1
+		ch <- 2
+		ch <- 3
+		close(ch)
+
+		keys, vals := seq.Unzip(seq.WithIndex(seq.YieldChan(ch)))
+
+		seqtest.AssertEqual(t, []int{1, 2, 3}, vals)
+		seqtest.AssertEqual(t, []int{0, 1, 2}, keys)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		keys, vals := seq.Unzip(seq.Empty2[string, int]())
+
+		seqtest.AssertEqual(t, nil, keys)
+		seqtest.AssertEqual(t, nil, vals)
+	})
+}
+
 func Test_Values(t *testing.T) {
 	tests := []struct {
 		name string
@@ -500,3 +572,152 @@ func Test_Zip(t *testing.T) {
 		})
 	}
 }
+
+func Test_Zip3(t *testing.T) {
+	tests := []struct {
+		name string
+		a    iter.Seq[string]
+		b    iter.Seq[int]
+		c    iter.Seq[bool]
+		want []seq.Triple[string, int, bool]
+	}{
+		{
+			name: "equal length",
+			a:    seq.Yield("a", "b"),
+			b:    seq.Yield(1, 2),
+			c:    seq.Yield(true, false),
+			want: []seq.Triple[string, int, bool]{
+				{First: "a", Second: 1, Third: true},
+				{First: "b", Second: 2, Third: false},
+			},
+		},
+		{
+			name: "shortest middle",
+			a:    seq.Yield("a", "b", "c"),
+			b:    seq.Yield(1),
+			c:    seq.Yield(true, false, true),
+			want: []seq.Triple[string, int, bool]{
+				{First: "a", Second: 1, Third: true},
+			},
+		},
+		{
+			name: "shortest last",
+			a:    seq.Yield("a", "b"),
+			b:    seq.Yield(1, 2),
+			c:    seq.Empty[bool](),
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := seq.Zip3(tt.a, tt.b, tt.c)
+			seqtest.AssertEqual(t, tt.want, got)
+		})
+	}
+}
+
+func Test_ZipLongest(t *testing.T) {
+	tests := []struct {
+		name string
+		keys iter.Seq[string]
+		vals iter.Seq[int]
+		want []seqtest.KeyValuePair[string, int]
+	}{
+		{
+			name: "more keys than values",
+			keys: seq.Yield("a", "b", "c"),
+			vals: seq.Yield(1),
+			want: []seqtest.KeyValuePair[string, int]{
+				{Key: "a", Value: 1},
+				{Key: "b", Value: -1},
+				{Key: "c", Value: -1},
+			},
+		},
+		{
+			name: "more values than keys",
+			keys: seq.Yield("a"),
+			vals: seq.Yield(1, 2),
+			want: []seqtest.KeyValuePair[string, int]{
+				{Key: "a", Value: 1},
+				{Key: "?", Value: 2},
+			},
+		},
+		{
+			name: "both empty",
+			keys: seq.Empty[string](),
+			vals: seq.Empty[int](),
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := seq.ZipLongest(tt.keys, tt.vals, "?", -1)
+			seqtest.AssertEqual2(t, tt.want, got)
+		})
+	}
+
+	t.Run("early return", func(t *testing.T) {
+		got := limitedCollector2(seq.ZipLongest(seq.Yield("a"), seq.Yield(1, 2, 3), "?", -1), 2)
+		assert.Len(t, got, 2)
+	})
+}
+
+func Test_ZipLongestFunc(t *testing.T) {
+	describe := func(a string, okA bool, b int, okB bool) string {
+		switch {
+		case okA && okB:
+			return a + "=" + toString(b)
+		case okA:
+			return a + "=<none>"
+		default:
+			return "<none>=" + toString(b)
+		}
+	}
+
+	got := seq.ZipLongestFunc(seq.Yield("a", "b"), seq.Yield(1, 2, 3, 4), describe)
+	seqtest.AssertEqual(t, []string{"a=1", "b=2", "<none>=3", "<none>=4"}, got)
+
+	got = seq.ZipLongestFunc(seq.Yield("a", "b"), seq.Empty[int](), describe)
+	seqtest.AssertEqual(t, []string{"a=<none>", "b=<none>"}, got)
+}
+
+func Test_ZipN(t *testing.T) {
+	tests := []struct {
+		name string
+		seqs []iter.Seq[int]
+		want [][]int
+	}{
+		{
+			name: "three sequences",
+			seqs: []iter.Seq[int]{seq.Yield(1, 2, 3), seq.Yield(4, 5, 6), seq.Yield(7, 8)},
+			want: [][]int{{1, 4, 7}, {2, 5, 8}},
+		},
+		{
+			name: "single sequence",
+			seqs: []iter.Seq[int]{seq.Yield(1, 2)},
+			want: [][]int{{1}, {2}},
+		},
+		{
+			name: "no sequences",
+			seqs: nil,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := seq.ZipN(tt.seqs...)
+			seqtest.AssertEqual(t, tt.want, got)
+		})
+	}
+}
+
+func Test_ZipWith(t *testing.T) {
+	sum := func(a, b int) int { return a + b }
+
+	seqtest.AssertEqual(t, []int{5, 7, 9}, seq.ZipWith(seq.Yield(1, 2, 3), seq.Yield(4, 5, 6), sum))
+	seqtest.AssertEqual(t, []int{5}, seq.ZipWith(seq.Yield(1, 2, 3), seq.Yield(4), sum))
+	seqtest.AssertEqual(t, nil, seq.ZipWith(seq.Empty[int](), seq.Yield(4), sum))
+}
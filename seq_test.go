@@ -3,6 +3,7 @@ package seq_test
 import (
 	"fmt"
 	"iter"
+	"strconv"
 	"testing"
 
 	"github.com/arielsrv/go-seq"
@@ -354,6 +355,51 @@ func Test_Chunk(t *testing.T) {
 	}
 }
 
+func Test_ChunkBy(t *testing.T) {
+	tests := []struct {
+		name string
+		seq  iter.Seq[int]
+		want []seqtest.KeyValuePair[bool, []int]
+	}{
+		{
+			name: "consecutive runs",
+			seq:  seq.Yield(2, 4, 1, 3, 6, 5),
+			want: []seqtest.KeyValuePair[bool, []int]{
+				{Key: true, Value: []int{2, 4}},
+				{Key: false, Value: []int{1, 3}},
+				{Key: true, Value: []int{6}},
+				{Key: false, Value: []int{5}},
+			},
+		},
+		{
+			name: "single run",
+			seq:  seq.Yield(1, 3),
+			want: []seqtest.KeyValuePair[bool, []int]{
+				{Key: false, Value: []int{1, 3}},
+			},
+		},
+		{
+			name: "empty sequence",
+			seq:  seq.Yield[int](),
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := seq.ChunkBy(tt.seq, isEven)
+			seqtest.AssertEqual2(t, tt.want, got)
+		})
+	}
+
+	t.Run("infinite sequence", func(t *testing.T) {
+		runs := seq.ChunkBy(naturals(), func(v int) int { return v / 3 })
+		got := seq.Values(runs)
+
+		assert.Equal(t, [][]int{{0, 1, 2}, {3, 4, 5}}, limitedCollector(got, 2))
+	})
+}
+
 func Test_Concat(t *testing.T) {
 	tests := []struct {
 		name string
@@ -496,6 +542,57 @@ func Test_CountFunc(t *testing.T) {
 	}
 }
 
+func Test_Cycle(t *testing.T) {
+	tests := []struct {
+		name string
+		seq  iter.Seq[int]
+		n    int
+		want []int
+	}{
+		{
+			name: "repeats values",
+			seq:  seq.Yield(1, 2, 3),
+			n:    7,
+			want: []int{1, 2, 3, 1, 2, 3, 1},
+		},
+		{
+			name: "stops during first pass",
+			seq:  seq.Yield(1, 2, 3),
+			n:    2,
+			want: []int{1, 2},
+		},
+		{
+			name: "single value",
+			seq:  seq.Yield(5),
+			n:    3,
+			want: []int{5, 5, 5},
+		},
+		{
+			name: "empty",
+			seq:  seq.Yield[int](),
+			n:    3,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := seq.Take(seq.Cycle(tt.seq), tt.n)
+			seqtest.AssertEqual(t, tt.want, got)
+		})
+	}
+}
+
+func Test_Cycle_SingleUse(t *testing.T) {
+	ch := make(chan int, 2)
+	ch <- 1
+	ch <- 2
+	close(ch)
+
+	got := seq.Take(seq.Cycle(seq.YieldChan(ch)), 5)
+	seqtest.AssertEqual(t, []int{1, 2, 1, 2, 1}, got)
+}
+
 func Test_Empty(t *testing.T) {
 	got := seq.Empty[int]()
 	seqtest.AssertEqual(t, nil, got)
@@ -684,6 +781,129 @@ func Test_FirstFunc(t *testing.T) {
 	}
 }
 
+func Test_Generate(t *testing.T) {
+	calls := 0
+	counter := func() int {
+		calls++
+		return calls * 10
+	}
+
+	got := seq.Take(seq.Generate(counter), 3)
+	seqtest.AssertEqual(t, []int{10, 20, 30}, got)
+	assert.Equal(t, 3, calls, "should not generate more values than taken")
+}
+
+func Test_Interleave(t *testing.T) {
+	tests := []struct {
+		name string
+		seqs []iter.Seq[int]
+		want []int
+	}{
+		{
+			name: "equal lengths",
+			seqs: []iter.Seq[int]{seq.Yield(1, 2), seq.Yield(10, 20), seq.Yield(100, 200)},
+			want: []int{1, 10, 100, 2, 20, 200},
+		},
+		{
+			name: "different lengths",
+			seqs: []iter.Seq[int]{seq.Yield(1), seq.Yield(10, 20, 30), seq.Yield(100, 200)},
+			want: []int{1, 10, 100, 20, 200, 30},
+		},
+		{
+			name: "empty sequences",
+			seqs: []iter.Seq[int]{seq.Yield[int](), seq.Yield(10), seq.Yield[int]()},
+			want: []int{10},
+		},
+		{
+			name: "no sequences",
+			seqs: nil,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := seq.Interleave(tt.seqs...)
+			seqtest.AssertEqual(t, tt.want, got)
+		})
+	}
+
+	t.Run("infinite sequences", func(t *testing.T) {
+		negatives := seq.Select(naturals(), func(v int) int { return -v })
+		got := seq.Interleave(naturals(), negatives)
+
+		assert.Equal(t, []int{0, 0, 1, -1, 2}, limitedCollector(got, 5))
+	})
+}
+
+func Test_Intersperse(t *testing.T) {
+	tests := []struct {
+		name string
+		seq  iter.Seq[string]
+		want []string
+	}{
+		{
+			name: "multiple values",
+			seq:  seq.Yield("a", "b", "c"),
+			want: []string{"a", ",", "b", ",", "c"},
+		},
+		{
+			name: "single value",
+			seq:  seq.Yield("a"),
+			want: []string{"a"},
+		},
+		{
+			name: "empty sequence",
+			seq:  seq.Yield[string](),
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := seq.Intersperse(tt.seq, ",")
+			seqtest.AssertEqual(t, tt.want, got)
+		})
+	}
+
+	t.Run("early return", func(t *testing.T) {
+		got := seq.Intersperse(seq.Yield("a", "b", "c"), ",")
+		assert.Equal(t, []string{"a", ","}, limitedCollector(got, 2))
+	})
+}
+
+func Test_Iterate(t *testing.T) {
+	tests := []struct {
+		name string
+		seq  iter.Seq[int]
+		want []int
+	}{
+		{
+			name: "take",
+			seq:  seq.Take(seq.Iterate(1, func(v int) int { return v * 2 }), 5),
+			want: []int{1, 2, 4, 8, 16},
+		},
+		{
+			name: "take while",
+			seq: seq.TakeWhile(seq.Iterate(10, func(v int) int { return v - 3 }), func(_, v int) bool {
+				return v > 0
+			}),
+			want: []int{10, 7, 4, 1},
+		},
+		{
+			name: "take none",
+			seq:  seq.Take(seq.Iterate(1, func(v int) int { return v + 1 }), 0),
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seqtest.AssertEqual(t, tt.want, tt.seq)
+		})
+	}
+}
+
 func Test_Last(t *testing.T) {
 	tests := []struct {
 		name string
@@ -1055,6 +1275,27 @@ func Test_OfType(t *testing.T) {
 	}
 }
 
+func Test_PartitionSeq(t *testing.T) {
+	t.Run("single-use sequence", func(t *testing.T) {
+		ch := make(chan int, 5)
+		for v := range seq.Yield(1, 2, 3, 4, 5) {
+			ch <- v
+		}
+		close(ch)
+
+		matching, rest := seq.PartitionSeq(seq.YieldChan(ch), isEven)
+		seqtest.AssertEqual(t, []int{2, 4}, matching)
+		seqtest.AssertEqual(t, []int{1, 3, 5}, rest)
+	})
+
+	t.Run("infinite sequence", func(t *testing.T) {
+		evens, odds := seq.PartitionSeq(naturals(), isEven)
+		pairs := seq.ZipWith(evens, odds, func(a, b int) [2]int { return [2]int{a, b} })
+
+		assert.Equal(t, [][2]int{{0, 1}, {2, 3}, {4, 5}}, limitedCollector(pairs, 3))
+	})
+}
+
 func Test_Prepend(t *testing.T) {
 	tests := []struct {
 		name string
@@ -1331,6 +1572,131 @@ func Test_Repeat_EdgeCases(t *testing.T) {
 	})
 }
 
+func Test_RepeatForever(t *testing.T) {
+	got := seq.Take(seq.RepeatForever("a"), 3)
+	seqtest.AssertEqual(t, []string{"a", "a", "a"}, got)
+}
+
+func Test_RoundRobin(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights []int
+		seqs    []iter.Seq[string]
+		want    []string
+	}{
+		{
+			name:    "weighted",
+			weights: []int{2, 1},
+			seqs:    []iter.Seq[string]{seq.Yield("a1", "a2", "a3", "a4"), seq.Yield("b1", "b2")},
+			want:    []string{"a1", "a2", "b1", "a3", "a4", "b2"},
+		},
+		{
+			name:    "exhausted sequence is skipped",
+			weights: []int{1, 3},
+			seqs:    []iter.Seq[string]{seq.Yield("a1", "a2", "a3"), seq.Yield("b1")},
+			want:    []string{"a1", "b1", "a2", "a3"},
+		},
+		{
+			name:    "no sequences",
+			weights: nil,
+			seqs:    nil,
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := seq.RoundRobin(tt.weights, tt.seqs...)
+			seqtest.AssertEqual(t, tt.want, got)
+		})
+	}
+
+	t.Run("sources are stopped", func(t *testing.T) {
+		stopped := 0
+		source := func(yield func(int) bool) {
+			defer func() { stopped++ }()
+
+			for v := range naturals() {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+
+		got := seq.RoundRobin([]int{1, 2}, source, source)
+		assert.Equal(t, []int{0, 0, 1, 1}, limitedCollector(got, 4))
+		assert.Equal(t, 2, stopped)
+	})
+
+	assert.Panics(t, func() { seq.RoundRobin([]int{1}, seq.Yield(1), seq.Yield(2)) })
+	assert.Panics(t, func() { seq.RoundRobin([]int{1, 0}, seq.Yield(1), seq.Yield(2)) })
+}
+
+func Test_RunningMax(t *testing.T) {
+	seqtest.AssertEqual(t, []int{3, 3, 4, 4, 5}, seq.RunningMax(seq.Yield(3, 1, 4, 1, 5)))
+	seqtest.AssertEqual(t, nil, seq.RunningMax(seq.Empty[int]()))
+}
+
+func Test_RunningMin(t *testing.T) {
+	seqtest.AssertEqual(t, []int{3, 1, 1, 1, 1}, seq.RunningMin(seq.Yield(3, 1, 4, 1, 5)))
+	seqtest.AssertEqual(t, []int{-1}, seq.RunningMin(seq.Yield(-1)))
+	seqtest.AssertEqual(t, nil, seq.RunningMin(seq.Empty[int]()))
+}
+
+func Test_RunningSum(t *testing.T) {
+	seqtest.AssertEqual(t, []int{1, 3, 6, 10}, seq.RunningSum(seq.Yield(1, 2, 3, 4)))
+	seqtest.AssertEqual(t, []float64{0.5, 2}, seq.RunningSum(seq.Yield(0.5, 1.5)))
+	seqtest.AssertEqual(t, nil, seq.RunningSum(seq.Empty[int]()))
+
+	t.Run("infinite sequence", func(t *testing.T) {
+		seqtest.AssertEqual(t, []int{0, 1, 3, 6}, seq.Take(seq.RunningSum(naturals()), 4))
+	})
+}
+
+func Test_Scan(t *testing.T) {
+	tests := []struct {
+		name string
+		seq  iter.Seq[int]
+		init string
+		want []string
+	}{
+		{
+			name: "intermediate results",
+			seq:  seq.Yield(1, 2, 3),
+			init: ">",
+			want: []string{">1", ">12", ">123"},
+		},
+		{
+			name: "single value",
+			seq:  seq.Yield(1),
+			init: "",
+			want: []string{"1"},
+		},
+		{
+			name: "empty sequence",
+			seq:  seq.Empty[int](),
+			init: ">",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := seq.Scan(tt.seq, tt.init, func(acc string, v int) string { return acc + strconv.Itoa(v) })
+			seqtest.AssertEqual(t, tt.want, got)
+		})
+	}
+
+	t.Run("until balance goes negative", func(t *testing.T) {
+		source, reads := countingYield(10, -3, -5, -4, 20)
+		balances := seq.Scan(source, 0, func(balance, v int) int { return balance + v })
+		positive := seq.TakeWhile(balances, func(_ int, balance int) bool { return balance >= 0 })
+
+		seqtest.AssertEqual(t, []int{10, 7, 2}, positive)
+		assert.Equal(t, 4, *reads, "the sequence is read lazily")
+	})
+}
+
 func Test_Select(t *testing.T) {
 	tests := []struct {
 		name string
@@ -1646,6 +2012,146 @@ func Test_SkipWhile(t *testing.T) {
 	}
 }
 
+func Test_SplitAt(t *testing.T) {
+	tests := []struct {
+		name     string
+		seq      iter.Seq[int]
+		n        int
+		wantHead []int
+		wantTail []int
+	}{
+		{
+			name:     "middle",
+			seq:      seq.Yield(1, 2, 3, 4, 5),
+			n:        2,
+			wantHead: []int{1, 2},
+			wantTail: []int{3, 4, 5},
+		},
+		{
+			name:     "beyond the end",
+			seq:      seq.Yield(1, 2),
+			n:        5,
+			wantHead: []int{1, 2},
+			wantTail: nil,
+		},
+		{
+			name:     "zero",
+			seq:      seq.Yield(1, 2),
+			n:        0,
+			wantHead: nil,
+			wantTail: []int{1, 2},
+		},
+		{
+			name:     "empty sequence",
+			seq:      seq.Yield[int](),
+			n:        2,
+			wantHead: nil,
+			wantTail: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			head, tail := seq.SplitAt(tt.seq, tt.n)
+			seqtest.AssertEqual(t, tt.wantHead, head)
+			seqtest.AssertEqual(t, tt.wantTail, tail)
+		})
+	}
+
+	t.Run("tail first", func(t *testing.T) {
+		source, reads := countingYield(1, 2, 3)
+		head, tail := seq.SplitAt(source, 1)
+
+		seqtest.AssertEqual(t, []int{2, 3}, tail)
+		seqtest.AssertEqual(t, []int{1}, head)
+		assert.Equal(t, 3, *reads, "the sequence is read only once")
+	})
+
+	t.Run("tail only", func(t *testing.T) {
+		allocs := testing.AllocsPerRun(5, func() {
+			_, tail := seq.SplitAt(seq.Take(naturals(), 100_000), 2)
+			assert.Equal(t, 99_998, seq.Count(tail))
+		})
+
+		// the values after the head are not buffered
+		assert.Less(t, allocs, float64(30))
+	})
+
+	t.Run("stops the sequence", func(t *testing.T) {
+		stopped := false
+		source := func(yield func(int) bool) {
+			defer func() { stopped = true }()
+
+			for v := range naturals() {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+
+		head, tail := seq.SplitAt(source, 3)
+		assert.Equal(t, []int{3, 4}, limitedCollector(tail, 2))
+		assert.False(t, stopped, "the head can still be read")
+
+		assert.Equal(t, []int{0, 1, 2}, seq.ToSlice(head))
+		assert.True(t, stopped)
+
+		seqtest.AssertEqual(t, nil, head)
+		seqtest.AssertEqual(t, nil, tail)
+	})
+}
+
+func Test_SplitOn(t *testing.T) {
+	tests := []struct {
+		name string
+		seq  iter.Seq[int]
+		want [][]int
+	}{
+		{
+			name: "separated values",
+			seq:  seq.Yield(1, 2, 0, 3, 0, 4),
+			want: [][]int{{1, 2}, {3}, {4}},
+		},
+		{
+			name: "separators at the ends",
+			seq:  seq.Yield(0, 1, 0),
+			want: [][]int{nil, {1}, nil},
+		},
+		{
+			name: "consecutive separators",
+			seq:  seq.Yield(1, 0, 0, 2),
+			want: [][]int{{1}, nil, {2}},
+		},
+		{
+			name: "no separators",
+			seq:  seq.Yield(1, 2),
+			want: [][]int{{1, 2}},
+		},
+		{
+			name: "empty sequence",
+			seq:  seq.Yield[int](),
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := seq.SplitOn(tt.seq, 0)
+			seqtest.AssertEqual(t, tt.want, got)
+		})
+	}
+}
+
+func Test_SplitWhen(t *testing.T) {
+	got := seq.SplitWhen(seq.Yield("a", "b", "", "c"), func(s string) bool { return s == "" })
+	seqtest.AssertEqual(t, [][]string{{"a", "b"}, {"c"}}, got)
+
+	t.Run("infinite sequence", func(t *testing.T) {
+		got := seq.SplitWhen(naturals(), func(v int) bool { return v%3 == 0 })
+		assert.Equal(t, [][]int{nil, {1, 2}, {4, 5}}, limitedCollector(got, 3))
+	})
+}
+
 func Test_Sum(t *testing.T) {
 	tests := []struct {
 		name string
@@ -1811,6 +2317,32 @@ func Test_TakeWhile(t *testing.T) {
 	}
 }
 
+func Test_Unfold(t *testing.T) {
+	t.Run("fibonacci", func(t *testing.T) {
+		fib := seq.Unfold([2]int{0, 1}, func(s [2]int) (int, [2]int, bool) {
+			return s[0], [2]int{s[1], s[0] + s[1]}, s[0] < 10
+		})
+		seqtest.AssertEqual(t, []int{0, 1, 1, 2, 3, 5, 8}, fib)
+	})
+
+	t.Run("digits", func(t *testing.T) {
+		digits := seq.Unfold(1234, func(n int) (int, int, bool) {
+			return n % 10, n / 10, n > 0
+		})
+		seqtest.AssertEqual(t, []int{4, 3, 2, 1}, digits)
+	})
+
+	t.Run("infinite", func(t *testing.T) {
+		evens := seq.Unfold(0, func(n int) (int, int, bool) { return n, n + 2, true })
+		seqtest.AssertEqual(t, []int{0, 2, 4}, seq.Take(evens, 3))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		none := seq.Unfold(0, func(n int) (int, int, bool) { return n, n, false })
+		seqtest.AssertEqual(t, nil, none)
+	})
+}
+
 func Test_ValueAt(t *testing.T) {
 	tests := []struct {
 		name  string
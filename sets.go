@@ -102,6 +102,51 @@ func DistinctKeys[K comparable, V any](seq iter.Seq2[K, V]) iter.Seq2[K, V] {
 	}
 }
 
+// DistinctBy returns distinct values from a sequence using a function to select a key to compare.
+//
+// The first value with each key is yielded, and any subsequent values with the same key are ignored.
+func DistinctBy[V any, K comparable](seq iter.Seq[V], f func(V) K) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		set := NewSet[K]()
+
+		for v := range seq {
+			if set.Add(f(v)) {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// DistinctUntilChanged returns values from a sequence that are not equal to the value before them.
+//
+// Only consecutive duplicates are ignored, so this does not need to remember previous values.
+func DistinctUntilChanged[V comparable](seq iter.Seq[V]) iter.Seq[V] {
+	return DistinctUntilChangedFunc(seq, func(a, b V) bool { return a == b })
+}
+
+// DistinctUntilChangedFunc returns values from a sequence that are not equal to the value before
+// them using a function to compare values.
+//
+// Only consecutive duplicates are ignored, so this does not need to remember previous values.
+func DistinctUntilChangedFunc[V any](seq iter.Seq[V], f func(V, V) bool) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		var prev V
+		first := true
+
+		for v := range seq {
+			if first || !f(prev, v) {
+				if !yield(v) {
+					return
+				}
+			}
+
+			prev, first = v, false
+		}
+	}
+}
+
 // Except returns values from a sequence that are not present in a set.
 func Except[V comparable](seq iter.Seq[V], vals Set[V]) iter.Seq[V] {
 	return func(yield func(V) bool) {
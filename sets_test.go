@@ -90,6 +90,74 @@ func Test_DistinctKeys(t *testing.T) {
 	}
 }
 
+func Test_DistinctBy(t *testing.T) {
+	tests := []struct {
+		name string
+		seq  iter.Seq[string]
+		want []string
+	}{
+		{
+			name: "distinct keys",
+			seq:  seq.Yield("a", "bb", "c", "dd", "eee"),
+			want: []string{"a", "bb", "eee"},
+		},
+		{
+			name: "empty",
+			seq:  seq.Yield[string](),
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := seq.DistinctBy(tt.seq, func(s string) int { return len(s) })
+			seqtest.AssertEqual(t, tt.want, got)
+		})
+	}
+}
+
+func Test_DistinctUntilChanged(t *testing.T) {
+	tests := []struct {
+		name string
+		seq  iter.Seq[int]
+		want []int
+	}{
+		{
+			name: "consecutive duplicates",
+			seq:  seq.Yield(1, 1, 2, 2, 2, 1, 3, 3),
+			want: []int{1, 2, 1, 3},
+		},
+		{
+			name: "zero value first",
+			seq:  seq.Yield(0, 0, 1),
+			want: []int{0, 1},
+		},
+		{
+			name: "empty",
+			seq:  seq.Yield[int](),
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := seq.DistinctUntilChanged(tt.seq)
+			seqtest.AssertEqual(t, tt.want, got)
+		})
+	}
+}
+
+func Test_DistinctUntilChangedFunc(t *testing.T) {
+	got := seq.DistinctUntilChangedFunc(seq.Yield([]int{1}, []int{1}, []int{2}, []int{1}), slices.Equal[[]int])
+	seqtest.AssertEqual(t, [][]int{{1}, {2}, {1}}, got)
+
+	t.Run("infinite sequence", func(t *testing.T) {
+		halves := seq.Select(naturals(), func(v int) int { return v / 2 })
+		got := seq.DistinctUntilChanged(halves)
+		assert.Equal(t, []int{0, 1, 2}, limitedCollector(got, 3))
+	})
+}
+
 func Test_Except(t *testing.T) {
 	tests := []struct {
 		name string
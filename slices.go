@@ -48,6 +48,20 @@ func CollectLast[V any](seq iter.Seq[V], n int) []V {
 	return buf
 }
 
+// Partition collects values from a sequence into two new slices: the values that satisfy
+// a condition and the values that do not.
+func Partition[V any](seq iter.Seq[V], f func(V) bool) (matching, rest []V) {
+	for v := range seq {
+		if f(v) {
+			matching = append(matching, v)
+		} else {
+			rest = append(rest, v)
+		}
+	}
+
+	return matching, rest
+}
+
 // Reversed collects values from a sequence into a new slice and then reverses it.
 func Reversed[V any](seq iter.Seq[V]) []V {
 	s := slices.Collect(seq)
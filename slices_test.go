@@ -97,6 +97,42 @@ func Test_Collect(t *testing.T) {
 	}
 }
 
+func Test_Partition(t *testing.T) {
+	tests := []struct {
+		name         string
+		seq          iter.Seq[int]
+		wantMatching []int
+		wantRest     []int
+	}{
+		{
+			name:         "mixed values",
+			seq:          seq.Yield(1, 2, 3, 4, 5),
+			wantMatching: []int{2, 4},
+			wantRest:     []int{1, 3, 5},
+		},
+		{
+			name:         "all matching",
+			seq:          seq.Yield(2, 4),
+			wantMatching: []int{2, 4},
+			wantRest:     nil,
+		},
+		{
+			name:         "empty sequence",
+			seq:          seq.Yield[int](),
+			wantMatching: nil,
+			wantRest:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matching, rest := seq.Partition(tt.seq, isEven)
+			assert.Equal(t, tt.wantMatching, matching)
+			assert.Equal(t, tt.wantRest, rest)
+		})
+	}
+}
+
 func Test_Reversed(t *testing.T) {
 	tests := []struct {
 		name string
@@ -0,0 +1,166 @@
+package seq
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// AsCompleted runs tasks concurrently and returns a sequence of their results in the order they
+// complete.
+//
+// Tasks are read from the sequence as they are needed, so at most concurrency tasks run at once.
+// Each task is called with a context that is cancelled when the returned sequence stops or the
+// given context is done; no more tasks are started after that, and the returned sequence waits
+// for the running tasks to return. Errors returned by tasks are yielded with their results and
+// do not stop the other tasks. If the given context is done before every task has started, the
+// returned sequence ends by yielding a zero value with the context's error, after the results of
+// the tasks that were started. If a task panics, the running tasks are cancelled and the panic
+// is raised again by the returned sequence.
+// Use [AsCompletedOrdered] to yield results in the order of the tasks.
+// This panics if concurrency is not positive.
+func AsCompleted[V any](
+	ctx context.Context,
+	tasks iter.Seq[func(context.Context) (V, error)],
+	concurrency int,
+) iter.Seq2[V, error] {
+	if concurrency <= 0 {
+		panic("seq.AsCompleted: concurrency must be positive")
+	}
+
+	return asCompleted(ctx, tasks, concurrency, false)
+}
+
+// AsCompletedOrdered runs tasks concurrently and returns a sequence of their results in the order
+// of the tasks.
+//
+// This works like [AsCompleted], except that a result is only yielded once the results of every
+// earlier task have been yielded. Results that complete early count towards the concurrency
+// limit until they are yielded, so a slow task delays the start of new ones.
+// This panics if concurrency is not positive.
+func AsCompletedOrdered[V any](
+	ctx context.Context,
+	tasks iter.Seq[func(context.Context) (V, error)],
+	concurrency int,
+) iter.Seq2[V, error] {
+	if concurrency <= 0 {
+		panic("seq.AsCompletedOrdered: concurrency must be positive")
+	}
+
+	return asCompleted(ctx, tasks, concurrency, true)
+}
+
+// taskResult is the result of a task started by [AsCompleted] or [AsCompletedOrdered].
+type taskResult[V any] struct {
+	val   V
+	err   error
+	index int
+}
+
+// asCompleted implements [AsCompleted] and [AsCompletedOrdered].
+func asCompleted[V any](
+	ctx context.Context,
+	tasks iter.Seq[func(context.Context) (V, error)],
+	concurrency int,
+	ordered bool,
+) iter.Seq2[V, error] {
+	return func(yield func(V, error) bool) {
+		parent := ctx
+		ctx, cancel := context.WithCancel(ctx)
+
+		// a slot is taken when a task starts and freed when its result is no longer held
+		slots := make(chan struct{}, concurrency)
+		results := make(chan taskResult[V])
+
+		var panicked goroutinePanic
+		var wg sync.WaitGroup
+
+		// dropped is set if a task was not started because the context was done
+		dropped := false
+
+		panicked.goCancel(&wg, cancel, func() {
+			index := 0
+			for task := range tasks {
+				select {
+				case slots <- struct{}{}:
+				case <-ctx.Done():
+				}
+
+				// a slot can be taken even if the context is already done
+				if ctx.Err() != nil {
+					dropped = true
+					return
+				}
+
+				i := index
+				index++
+
+				panicked.goCancel(&wg, cancel, func() {
+					v, err := task(ctx)
+					results <- taskResult[V]{val: v, err: err, index: i}
+				})
+			}
+		})
+
+		go func() {
+			wg.Wait()
+			close(results)
+		}()
+
+		defer func() {
+			cancel()
+
+			// wait for the running tasks to return
+			for range results {
+			}
+
+			panicked.repanic()
+		}()
+
+		// yieldDropped reports tasks that were not started, once every result has been received
+		yieldDropped := func() {
+			if err := parent.Err(); dropped && err != nil {
+				var zero V
+				yield(zero, err)
+			}
+		}
+
+		if !ordered {
+			for r := range results {
+				<-slots
+
+				if !yield(r.val, r.err) {
+					return
+				}
+			}
+
+			yieldDropped()
+
+			return
+		}
+
+		pending := make(map[int]taskResult[V])
+		next := 0
+
+		for r := range results {
+			pending[r.index] = r
+
+			for {
+				r, ok := pending[next]
+				if !ok {
+					break
+				}
+
+				delete(pending, next)
+				next++
+				<-slots
+
+				if !yield(r.val, r.err) {
+					return
+				}
+			}
+		}
+
+		yieldDropped()
+	}
+}
@@ -0,0 +1,210 @@
+package seq_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/arielsrv/go-seq"
+	"github.com/arielsrv/go-seq/internal/seqtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type task = func(context.Context) (int, error)
+
+// sleepTasks returns tasks that each sleep for the given number of milliseconds and return it.
+func sleepTasks(millis ...int) iter.Seq[task] {
+	return seq.Select(seq.Yield(millis...), func(ms int) task {
+		return func(ctx context.Context) (int, error) {
+			select {
+			case <-time.After(time.Duration(ms) * time.Millisecond):
+				return ms, nil
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			}
+		}
+	})
+}
+
+// trackedTasks returns n tasks that return their index and records the maximum number of
+// tasks running at once.
+func trackedTasks(n int, maxRunning *atomic.Int32) iter.Seq[task] {
+	var running atomic.Int32
+
+	return seq.Select(seq.Take(naturals(), n), func(i int) task {
+		return func(context.Context) (int, error) {
+			r := running.Add(1)
+			defer running.Add(-1)
+
+			for {
+				m := maxRunning.Load()
+				if r <= m || maxRunning.CompareAndSwap(m, r) {
+					break
+				}
+			}
+
+			time.Sleep(time.Millisecond)
+
+			return i, nil
+		}
+	})
+}
+
+func Test_AsCompleted(t *testing.T) {
+	t.Run("completion order", func(t *testing.T) {
+		var got []int
+		for v, err := range seq.AsCompleted(context.Background(), sleepTasks(60, 1, 30), 3) {
+			require.NoError(t, err)
+			got = append(got, v)
+		}
+
+		assert.Equal(t, []int{1, 30, 60}, got)
+	})
+
+	t.Run("limits concurrency", func(t *testing.T) {
+		var maxRunning atomic.Int32
+
+		results := seq.AsCompleted(context.Background(), trackedTasks(50, &maxRunning), 4)
+
+		assert.ElementsMatch(t, seq.ToSlice(seq.Take(naturals(), 50)), seq.ToSlice(seq.Keys(results)))
+		assert.LessOrEqual(t, maxRunning.Load(), int32(4))
+	})
+
+	t.Run("errors are yielded", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		tasks := seq.Yield[task](
+			func(context.Context) (int, error) { return 0, errBoom },
+			func(context.Context) (int, error) { return 1, nil },
+		)
+
+		errs := seq.ToSlice(seq.Values(seq.AsCompleted(context.Background(), tasks, 1)))
+		assert.Equal(t, []error{errBoom, nil}, errs)
+	})
+
+	t.Run("consumer stops early", func(t *testing.T) {
+		var started, cancelled atomic.Int32
+
+		tasks := seq.Select(seq.Take(naturals(), 100), func(i int) task {
+			return func(ctx context.Context) (int, error) {
+				started.Add(1)
+
+				if i == 0 {
+					return i, nil
+				}
+
+				<-ctx.Done()
+				cancelled.Add(1)
+
+				return i, ctx.Err()
+			}
+		})
+
+		for v := range seq.AsCompleted(context.Background(), tasks, 3) {
+			assert.Equal(t, 0, v)
+			break
+		}
+
+		// every task that was started has returned
+		assert.Equal(t, started.Load()-1, cancelled.Load())
+		assert.LessOrEqual(t, started.Load(), int32(4))
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var got []error
+		for v, err := range seq.AsCompleted(ctx, sleepTasks(1000, 1000), 1) {
+			assert.Equal(t, 0, v)
+			got = append(got, err)
+		}
+
+		// no task is started, and the dropped tasks are reported
+		assert.Equal(t, []error{context.Canceled}, got)
+	})
+
+	t.Run("context cancelled while running", func(t *testing.T) {
+		runs := []func(context.Context, iter.Seq[task], int) iter.Seq2[int, error]{
+			seq.AsCompleted[int],
+			seq.AsCompletedOrdered[int],
+		}
+
+		for _, run := range runs {
+			ctx, cancel := context.WithCancel(context.Background())
+
+			var started atomic.Int32
+			tasks := seq.Select(seq.Take(naturals(), 5), func(i int) task {
+				return func(ctx context.Context) (int, error) {
+					started.Add(1)
+
+					if i == 1 {
+						cancel()
+						<-ctx.Done()
+
+						return i, ctx.Err()
+					}
+
+					return i, nil
+				}
+			})
+
+			var got []seqtest.KeyValuePair[int, error]
+			for v, err := range run(ctx, tasks, 1) {
+				got = append(got, seqtest.KeyValuePair[int, error]{Key: v, Value: err})
+			}
+
+			assert.Equal(t, []seqtest.KeyValuePair[int, error]{
+				{Key: 0, Value: nil},
+				{Key: 1, Value: context.Canceled},
+				{Key: 0, Value: context.Canceled},
+			}, got)
+			assert.Equal(t, int32(2), started.Load(), "no task is started after the cancellation")
+		}
+	})
+
+	t.Run("panic is raised", func(t *testing.T) {
+		tasks := seq.Yield[task](
+			func(context.Context) (int, error) { panic("boom") },
+			func(ctx context.Context) (int, error) {
+				<-ctx.Done()
+				return 0, ctx.Err()
+			},
+		)
+
+		assert.PanicsWithValue(t, "boom", func() { seq.Count(seq.Keys(seq.AsCompleted(context.Background(), tasks, 2))) })
+	})
+
+	assert.Panics(t, func() { seq.AsCompleted(context.Background(), sleepTasks(), 0) })
+}
+
+func Test_AsCompletedOrdered(t *testing.T) {
+	t.Run("task order", func(t *testing.T) {
+		var got []int
+		for v, err := range seq.AsCompletedOrdered(context.Background(), sleepTasks(30, 1, 10, 1), 4) {
+			require.NoError(t, err)
+			got = append(got, v)
+		}
+
+		assert.Equal(t, []int{30, 1, 10, 1}, got)
+	})
+
+	t.Run("limits concurrency", func(t *testing.T) {
+		var maxRunning atomic.Int32
+
+		results := seq.AsCompletedOrdered(context.Background(), trackedTasks(50, &maxRunning), 4)
+
+		assert.Equal(t, seq.ToSlice(seq.Take(naturals(), 50)), seq.ToSlice(seq.Keys(results)))
+		assert.LessOrEqual(t, maxRunning.Load(), int32(4))
+	})
+
+	t.Run("early return", func(t *testing.T) {
+		results := seq.Keys(seq.AsCompletedOrdered(context.Background(), sleepTasks(5, 1, 1, 1), 2))
+		assert.Equal(t, []int{5, 1}, limitedCollector(results, 2))
+	})
+
+	assert.Panics(t, func() { seq.AsCompletedOrdered(context.Background(), sleepTasks(), -1) })
+}
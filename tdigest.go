@@ -0,0 +1,323 @@
+package seq
+
+import (
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"iter"
+	"math"
+	"slices"
+)
+
+// tdigestVersion is the version of the binary encoding written by [TDigest.MarshalBinary].
+const tdigestVersion = 1
+
+// defaultTDigestCompression is the compression of a zero [TDigest].
+const defaultTDigestCompression = 100
+
+// TDigest is a mergeable sketch for estimating quantiles of a stream of values
+// in bounded memory.
+//
+// The sketch keeps a small number of weighted centroids. The compression parameter
+// controls the trade-off between size and accuracy: a digest holds roughly
+// compression centroids, and values near the tails (p1, p99) are estimated more
+// accurately than values near the median.
+//
+// Digests built over separate partitions of a stream can be combined with [TDigest.Merge].
+// The zero value is an empty digest with a compression of 100.
+//
+// A digest is not safe for concurrent use, even for reads only: [TDigest.Quantile] and
+// [TDigest.MarshalBinary] merge buffered values into the centroids, which modifies the digest.
+//
+// Example:
+//
+//	// per-key latency digests
+//	digests := seq.AggregateGrouped(latencies,
+//		func(string) *seq.TDigest { return seq.NewTDigest(100) },
+//		func(d *seq.TDigest, v float64) *seq.TDigest { d.Add(v); return d },
+//	)
+//	p99 := digests["checkout"].Quantile(0.99)
+type TDigest struct {
+	centroids   []centroid
+	buffer      []centroid
+	compression float64
+	count       float64
+	min         float64
+	max         float64
+}
+
+// centroid is a weighted mean of a cluster of values.
+type centroid struct {
+	mean   float64
+	weight float64
+}
+
+// NewTDigest creates an empty digest with the given compression.
+//
+// A compression of 100 is a reasonable default; larger values increase accuracy and size.
+// This panics if compression is not positive.
+func NewTDigest(compression float64) *TDigest {
+	if !(compression > 0) {
+		panic("seq.NewTDigest: compression must be positive")
+	}
+
+	return &TDigest{compression: compression}
+}
+
+// CollectTDigest collects values from a sequence into a new digest with the given compression.
+func CollectTDigest(seq iter.Seq[float64], compression float64) *TDigest {
+	d := NewTDigest(compression)
+	for v := range seq {
+		d.Add(v)
+	}
+
+	return d
+}
+
+// Add adds a value to the digest.
+//
+// NaN and infinite values are ignored.
+func (d *TDigest) Add(v float64) {
+	d.AddWeighted(v, 1)
+}
+
+// AddWeighted adds a value to the digest with the given weight.
+//
+// NaN and infinite values, and non-positive or infinite weights, are ignored.
+func (d *TDigest) AddWeighted(v, weight float64) {
+	if math.IsNaN(v) || math.IsInf(v, 0) || !(weight > 0) || math.IsInf(weight, 1) {
+		return
+	}
+
+	if d.count == 0 {
+		d.min, d.max = v, v
+	}
+
+	d.buffer = append(d.buffer, centroid{mean: v, weight: weight})
+	d.count += weight
+	d.min = min(d.min, v)
+	d.max = max(d.max, v)
+
+	if len(d.buffer) >= d.bufferLimit() {
+		d.compress()
+	}
+}
+
+// Count returns the total weight of the values added to the digest.
+func (d *TDigest) Count() float64 {
+	return d.count
+}
+
+// Merge adds the values summarized by another digest to this digest.
+//
+// The other digest is not modified.
+func (d *TDigest) Merge(other *TDigest) {
+	if other == nil || other.count == 0 {
+		return
+	}
+
+	if d.count == 0 {
+		d.min, d.max = other.min, other.max
+	}
+
+	d.buffer = append(d.buffer, other.centroids...)
+	d.buffer = append(d.buffer, other.buffer...)
+	d.count += other.count
+	d.min = min(d.min, other.min)
+	d.max = max(d.max, other.max)
+
+	d.compress()
+}
+
+// Quantile returns the estimated value at quantile q, where q is between 0 and 1.
+//
+// This returns NaN if the digest is empty. Quantiles outside of [0, 1] are clamped.
+// This merges buffered values into the centroids, so it modifies the digest.
+func (d *TDigest) Quantile(q float64) float64 {
+	d.compress()
+
+	if d.count == 0 || math.IsNaN(q) {
+		return math.NaN()
+	}
+
+	switch {
+	case q <= 0:
+		return d.min
+
+	case q >= 1:
+		return d.max
+
+	case len(d.centroids) == 1:
+		return d.centroids[0].mean
+	}
+
+	cs := d.centroids
+	index := q * d.count
+
+	// interpolate between the minimum and the center of the first centroid
+	if first := cs[0]; index < first.weight/2 {
+		return d.min + (first.mean-d.min)*index/(first.weight/2)
+	}
+
+	// interpolate between the centers of adjacent centroids
+	cumulative := 0.0
+	for i := range len(cs) - 1 {
+		left := cumulative + cs[i].weight/2
+		right := cumulative + cs[i].weight + cs[i+1].weight/2
+
+		if index <= right {
+			t := (index - left) / (right - left)
+			return cs[i].mean + (cs[i+1].mean-cs[i].mean)*t
+		}
+
+		cumulative += cs[i].weight
+	}
+
+	// interpolate between the center of the last centroid and the maximum
+	last := cs[len(cs)-1]
+	t := (index - (d.count - last.weight/2)) / (last.weight / 2)
+
+	return last.mean + (d.max-last.mean)*min(t, 1)
+}
+
+// MarshalBinary encodes the digest into a binary form.
+//
+// This merges buffered values into the centroids, so it modifies the digest.
+func (d *TDigest) MarshalBinary() ([]byte, error) {
+	d.compress()
+
+	buf := make([]byte, 0, 1+8*4+16*len(d.centroids))
+	buf = append(buf, tdigestVersion)
+	buf = binary.BigEndian.AppendUint64(buf, math.Float64bits(d.compressionOrDefault()))
+	buf = binary.BigEndian.AppendUint64(buf, math.Float64bits(d.min))
+	buf = binary.BigEndian.AppendUint64(buf, math.Float64bits(d.max))
+	buf = binary.BigEndian.AppendUint64(buf, uint64(len(d.centroids)))
+
+	for _, c := range d.centroids {
+		buf = binary.BigEndian.AppendUint64(buf, math.Float64bits(c.mean))
+		buf = binary.BigEndian.AppendUint64(buf, math.Float64bits(c.weight))
+	}
+
+	return buf, nil
+}
+
+// UnmarshalBinary decodes a digest from the binary form produced by [TDigest.MarshalBinary],
+// replacing the contents of the digest.
+func (d *TDigest) UnmarshalBinary(data []byte) error {
+	const headerSize = 1 + 8*4
+
+	if len(data) < headerSize {
+		return errors.New("seq.TDigest: data too short")
+	}
+
+	if data[0] != tdigestVersion {
+		return errors.New("seq.TDigest: unsupported encoding version")
+	}
+
+	readFloat := func(b []byte) float64 {
+		return math.Float64frombits(binary.BigEndian.Uint64(b))
+	}
+
+	compression := readFloat(data[1:])
+	if !(compression > 0) {
+		return errors.New("seq.TDigest: invalid compression")
+	}
+
+	minVal, maxVal := readFloat(data[9:]), readFloat(data[17:])
+	n := binary.BigEndian.Uint64(data[25:])
+	data = data[headerSize:]
+
+	if len(data)%16 != 0 || uint64(len(data)/16) != n {
+		return errors.New("seq.TDigest: data length does not match centroid count")
+	}
+
+	centroids := make([]centroid, n)
+	count := 0.0
+
+	for i := range centroids {
+		c := centroid{mean: readFloat(data[i*16:]), weight: readFloat(data[i*16+8:])}
+		if math.IsNaN(c.mean) || math.IsInf(c.mean, 0) || !(c.weight > 0) || math.IsInf(c.weight, 1) {
+			return errors.New("seq.TDigest: invalid centroid")
+		}
+
+		centroids[i] = c
+		count += c.weight
+	}
+
+	*d = TDigest{
+		centroids:   centroids,
+		compression: compression,
+		count:       count,
+		min:         minVal,
+		max:         maxVal,
+	}
+
+	return nil
+}
+
+// compressionOrDefault returns the compression of the digest, or the default compression for
+// a zero digest.
+func (d *TDigest) compressionOrDefault() float64 {
+	if d.compression == 0 {
+		return defaultTDigestCompression
+	}
+
+	return d.compression
+}
+
+// bufferLimit returns the number of buffered values that triggers a compression.
+func (d *TDigest) bufferLimit() int {
+	return int(math.Ceil(d.compressionOrDefault())) * 5
+}
+
+// compress merges buffered values into the centroids.
+//
+// Adjacent centroids are merged as long as the merged centroid stays within the size limit
+// given by the k1 scale function, which keeps centroids small near the tails.
+func (d *TDigest) compress() {
+	if len(d.buffer) == 0 {
+		return
+	}
+
+	all := append(d.buffer, d.centroids...)
+	slices.SortFunc(all, func(a, b centroid) int {
+		return cmp.Compare(a.mean, b.mean)
+	})
+
+	merged := make([]centroid, 0, len(d.centroids)+1)
+	current := all[0]
+	weightSoFar := 0.0
+	limit := d.quantileLimit(0)
+
+	for _, next := range all[1:] {
+		if (weightSoFar+current.weight+next.weight)/d.count <= limit {
+			// merge into the current centroid
+			current.weight += next.weight
+			current.mean += (next.mean - current.mean) * next.weight / current.weight
+
+			continue
+		}
+
+		merged = append(merged, current)
+		weightSoFar += current.weight
+		limit = d.quantileLimit(weightSoFar / d.count)
+		current = next
+	}
+
+	d.centroids = append(merged, current)
+	d.buffer = d.buffer[:0]
+}
+
+// quantileLimit returns the largest quantile a centroid starting at quantile q may extend to.
+func (d *TDigest) quantileLimit(q float64) float64 {
+	compression := d.compressionOrDefault()
+
+	k := compression / (2 * math.Pi) * math.Asin(2*q-1)
+	k++
+
+	if k >= compression/4 {
+		return 1
+	}
+
+	return (math.Sin(k*2*math.Pi/compression) + 1) / 2
+}
@@ -0,0 +1,196 @@
+package seq_test
+
+import (
+	"iter"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/arielsrv/go-seq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// uniformFloats returns a sequence of n shuffled values from 1 to n.
+func uniformFloats(n int) iter.Seq[float64] {
+	vals := make([]float64, n)
+	for i := range vals {
+		vals[i] = float64(i + 1)
+	}
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	rng.Shuffle(len(vals), func(i, j int) { vals[i], vals[j] = vals[j], vals[i] })
+
+	return seq.Yield(vals...)
+}
+
+func Test_TDigest_Quantile(t *testing.T) {
+	d := seq.CollectTDigest(uniformFloats(10000), 100)
+
+	tests := []struct {
+		name      string
+		q         float64
+		want      float64
+		tolerance float64
+	}{
+		{name: "min", q: 0, want: 1, tolerance: 0},
+		{name: "p1", q: 0.01, want: 100, tolerance: 5},
+		{name: "p50", q: 0.5, want: 5000, tolerance: 100},
+		{name: "p90", q: 0.9, want: 9000, tolerance: 50},
+		{name: "p99", q: 0.99, want: 9900, tolerance: 5},
+		{name: "max", q: 1, want: 10000, tolerance: 0},
+		{name: "clamped", q: 2, want: 10000, tolerance: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := d.Quantile(tt.q)
+			assert.InDelta(t, tt.want, got, tt.tolerance)
+		})
+	}
+
+	assert.InDelta(t, 10000, d.Count(), 0)
+}
+
+func Test_TDigest_Small(t *testing.T) {
+	tests := []struct {
+		name string
+		vals []float64
+		q    float64
+		want float64
+	}{
+		{name: "empty", vals: nil, q: 0.5, want: math.NaN()},
+		{name: "single", vals: []float64{42}, q: 0.5, want: 42},
+		{name: "two values median", vals: []float64{1, 3}, q: 0.5, want: 2},
+		{name: "ignores NaN", vals: []float64{math.NaN(), 7}, q: 0.9, want: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := seq.CollectTDigest(seq.Yield(tt.vals...), 100).Quantile(tt.q)
+			if math.IsNaN(tt.want) {
+				assert.True(t, math.IsNaN(got))
+				return
+			}
+
+			assert.InDelta(t, tt.want, got, 1e-9)
+		})
+	}
+}
+
+func Test_TDigest_Merge(t *testing.T) {
+	vals := slices.Collect(uniformFloats(10000))
+
+	left := seq.CollectTDigest(seq.Yield(vals[:5000]...), 100)
+	right := seq.CollectTDigest(seq.Yield(vals[5000:]...), 100)
+	left.Merge(right)
+
+	assert.InDelta(t, 10000, left.Count(), 0)
+	assert.InDelta(t, 5000, left.Quantile(0.5), 100)
+	assert.InDelta(t, 9900, left.Quantile(0.99), 10)
+	assert.InDelta(t, 1, left.Quantile(0), 0)
+	assert.InDelta(t, 10000, left.Quantile(1), 0)
+
+	// merging nil or empty digests is a no-op
+	left.Merge(nil)
+	left.Merge(seq.NewTDigest(100))
+	assert.InDelta(t, 10000, left.Count(), 0)
+}
+
+func Test_TDigest_IgnoresNaNAndInf(t *testing.T) {
+	d := seq.CollectTDigest(seq.Yield(math.Inf(1), 1, math.NaN(), 2, math.Inf(-1)), 100)
+	d.AddWeighted(3, math.Inf(1))
+
+	assert.InDelta(t, 2, d.Count(), 0)
+	assert.InDelta(t, 1, d.Quantile(0), 0)
+	assert.InDelta(t, 2, d.Quantile(0.9), 0.5)
+	assert.InDelta(t, 2, d.Quantile(1), 0)
+}
+
+func Test_TDigest_ZeroValue(t *testing.T) {
+	var d seq.TDigest
+	assert.True(t, math.IsNaN(d.Quantile(0.5)))
+
+	d.Add(5)
+	assert.InDelta(t, 5, d.Quantile(0), 0)
+	assert.InDelta(t, 5, d.Quantile(1), 0)
+
+	for v := range uniformFloats(10000) {
+		d.Add(v)
+	}
+
+	assert.InDelta(t, 1, d.Quantile(0), 0)
+	assert.InDelta(t, 5000, d.Quantile(0.5), 100)
+	assert.InDelta(t, 9900, d.Quantile(0.99), 5)
+
+	var merged seq.TDigest
+	merged.Merge(seq.CollectTDigest(seq.Yield(3.0, 4.0), 100))
+	assert.InDelta(t, 3, merged.Quantile(0), 0)
+	assert.InDelta(t, 4, merged.Quantile(1), 0)
+
+	data, err := new(seq.TDigest).MarshalBinary()
+	require.NoError(t, err)
+	require.NoError(t, new(seq.TDigest).UnmarshalBinary(data))
+}
+
+func Test_TDigest_AggregateGrouped(t *testing.T) {
+	keys := seq.Yield("a", "b", "a", "b", "a")
+	vals := seq.Yield(1.0, 10.0, 2.0, 20.0, 3.0)
+
+	digests := seq.AggregateGrouped(seq.Zip(keys, vals),
+		func(string) *seq.TDigest { return seq.NewTDigest(100) },
+		func(d *seq.TDigest, v float64) *seq.TDigest {
+			d.Add(v)
+			return d
+		},
+	)
+
+	require.Len(t, digests, 2)
+	assert.InDelta(t, 2, digests["a"].Quantile(0.5), 1e-9)
+	assert.InDelta(t, 15, digests["b"].Quantile(0.5), 1e-9)
+}
+
+func Test_TDigest_MarshalBinary(t *testing.T) {
+	d := seq.CollectTDigest(uniformFloats(1000), 50)
+
+	data, err := d.MarshalBinary()
+	require.NoError(t, err)
+
+	var decoded seq.TDigest
+	require.NoError(t, decoded.UnmarshalBinary(data))
+
+	assert.InDelta(t, d.Count(), decoded.Count(), 0)
+	for _, q := range []float64{0, 0.1, 0.5, 0.99, 1} {
+		assert.InDelta(t, d.Quantile(q), decoded.Quantile(q), 1e-9)
+	}
+
+	// decoded digests keep accepting values
+	decoded.Add(5000)
+	assert.InDelta(t, 5000, decoded.Quantile(1), 0)
+}
+
+func Test_TDigest_UnmarshalBinary_Invalid(t *testing.T) {
+	valid, err := seq.CollectTDigest(seq.Yield(1.0, 2.0), 100).MarshalBinary()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "empty", data: nil},
+		{name: "bad version", data: append([]byte{99}, valid[1:]...)},
+		{name: "truncated", data: valid[:len(valid)-1]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d seq.TDigest
+			assert.Error(t, d.UnmarshalBinary(tt.data))
+		})
+	}
+}
+
+func Test_NewTDigest_Panics(t *testing.T) {
+	assert.Panics(t, func() { seq.NewTDigest(0) })
+}
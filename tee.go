@@ -0,0 +1,250 @@
+package seq
+
+import (
+	"iter"
+	"sync"
+	"sync/atomic"
+)
+
+// Tee splits a sequence into n independent sequences that each yield all of its values.
+//
+// The given sequence is iterated only once, so this works with single-use sequences, such as
+// those from [YieldChan]. Values are buffered until they have been read by every returned
+// sequence, so the buffer only grows as far as the slowest sequence is behind the fastest one.
+// A sequence that is never iterated holds on to every value.
+//
+// Each returned sequence can be iterated only once; once it stops, it no longer holds
+// buffered values. The returned sequences are meant to be consumed from a single goroutine,
+// for example one after the other or interleaved with [iter.Pull].
+// Use [Broadcast] for consumers running in separate goroutines.
+// This panics if n is negative.
+func Tee[V any](seq iter.Seq[V], n int) []iter.Seq[V] {
+	if n < 0 {
+		panic("seq.Tee: n must be non-negative")
+	}
+
+	t := &tee[V]{seq: seq, pos: make([]int, n)}
+
+	seqs := make([]iter.Seq[V], n)
+	for i := range seqs {
+		seqs[i] = t.consumer(i)
+	}
+
+	return seqs
+}
+
+// tee holds the shared state of the sequences returned by [Tee].
+type tee[V any] struct {
+	seq  iter.Seq[V]
+	next func() (V, bool)
+	stop func()
+
+	// buf holds the values from index offset onwards that have not been read by every consumer
+	buf    []V
+	offset int
+
+	// pos holds the index of the next value of each consumer, or -1 once it has stopped
+	pos  []int
+	done bool
+}
+
+// consumer returns the sequence for consumer i.
+func (t *tee[V]) consumer(i int) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		if t.pos[i] < 0 {
+			return
+		}
+
+		defer t.detach(i)
+
+		for {
+			v, ok := t.at(t.pos[i])
+			if !ok {
+				return
+			}
+
+			t.pos[i]++
+			t.trim()
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// at returns the value at index i, reading it from the sequence if needed.
+func (t *tee[V]) at(i int) (V, bool) {
+	if i < t.offset+len(t.buf) {
+		return t.buf[i-t.offset], true
+	}
+
+	var zero V
+	if t.done {
+		return zero, false
+	}
+
+	if t.next == nil {
+		t.next, t.stop = iter.Pull(t.seq)
+	}
+
+	v, ok := t.next()
+	if !ok {
+		t.finish()
+		return zero, false
+	}
+
+	t.buf = append(t.buf, v)
+
+	return v, true
+}
+
+// detach stops consumer i from holding buffered values.
+func (t *tee[V]) detach(i int) {
+	t.pos[i] = -1
+	t.trim()
+}
+
+// trim drops buffered values that have been read by every active consumer, and stops
+// the sequence if there are no active consumers.
+func (t *tee[V]) trim() {
+	lowest := t.lowest()
+	if lowest < 0 {
+		t.finish()
+		t.buf = nil
+
+		return
+	}
+
+	if drop := lowest - t.offset; drop > 0 {
+		clear(t.buf[:drop])
+		t.buf = t.buf[drop:]
+		t.offset = lowest
+	}
+}
+
+// lowest returns the lowest position of the active consumers, or -1 if there are none.
+func (t *tee[V]) lowest() int {
+	lowest := -1
+	for _, p := range t.pos {
+		if p >= 0 && (lowest < 0 || p < lowest) {
+			lowest = p
+		}
+	}
+
+	return lowest
+}
+
+// finish stops the sequence; values that were already buffered remain available.
+func (t *tee[V]) finish() {
+	if t.stop != nil && !t.done {
+		t.stop()
+	}
+
+	t.done = true
+}
+
+// Broadcast splits a sequence into n independent sequences that each yield all of its values,
+// for consumers running in separate goroutines.
+//
+// The given sequence is iterated once in a background goroutine, which starts when the first
+// returned sequence is iterated. Each returned sequence buffers up to bufSize values; when a
+// consumer's buffer is full, the producer waits for it, so the fastest consumer can be at most
+// bufSize values ahead of the slowest one. Every returned sequence must therefore be iterated,
+// otherwise the other consumers stall once their lead reaches bufSize.
+//
+// A consumer that stops early no longer receives values, and the producer stops when every
+// consumer has stopped. If the given sequence panics, the panic is raised again in every
+// consumer once it has received the values yielded before the panic.
+// Each returned sequence can be iterated only once.
+// This panics if n or bufSize are negative.
+func Broadcast[V any](seq iter.Seq[V], n, bufSize int) []iter.Seq[V] {
+	if n < 0 {
+		panic("seq.Broadcast: n must be non-negative")
+	}
+
+	if bufSize < 0 {
+		panic("seq.Broadcast: bufSize must be non-negative")
+	}
+
+	b := &broadcast[V]{
+		seq:   seq,
+		chans: make([]chan V, n),
+		quits: make([]chan struct{}, n),
+		used:  make([]atomic.Bool, n),
+	}
+
+	seqs := make([]iter.Seq[V], n)
+	for i := range seqs {
+		b.chans[i] = make(chan V, bufSize)
+		b.quits[i] = make(chan struct{})
+		seqs[i] = b.consumer(i)
+	}
+
+	return seqs
+}
+
+// broadcast holds the shared state of the sequences returned by [Broadcast].
+type broadcast[V any] struct {
+	seq      iter.Seq[V]
+	chans    []chan V
+	quits    []chan struct{}
+	used     []atomic.Bool
+	panicked goroutinePanic
+	start    sync.Once
+}
+
+// consumer returns the sequence for consumer i.
+func (b *broadcast[V]) consumer(i int) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		if b.used[i].Swap(true) {
+			return
+		}
+
+		defer close(b.quits[i])
+
+		b.start.Do(func() { go b.produce() })
+
+		for v := range b.chans[i] {
+			if !yield(v) {
+				return
+			}
+		}
+
+		b.panicked.repanic()
+	}
+}
+
+// produce sends every value of the sequence to each consumer that has not stopped.
+func (b *broadcast[V]) produce() {
+	defer func() {
+		for _, ch := range b.chans {
+			close(ch)
+		}
+	}()
+
+	defer b.panicked.capture()
+
+	for v := range b.seq {
+		active := 0
+
+		for i, ch := range b.chans {
+			select {
+			case <-b.quits[i]:
+				// the consumer has stopped
+				continue
+			default:
+			}
+
+			select {
+			case ch <- v:
+				active++
+			case <-b.quits[i]:
+			}
+		}
+
+		if active == 0 {
+			return
+		}
+	}
+}
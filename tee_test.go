@@ -0,0 +1,156 @@
+package seq_test
+
+import (
+	"iter"
+	"sync"
+	"testing"
+
+	"github.com/arielsrv/go-seq"
+	"github.com/arielsrv/go-seq/internal/seqtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Tee(t *testing.T) {
+	t.Run("sequential consumers", func(t *testing.T) {
+		source, reads := countingYield(1, 2, 3)
+		seqs := seq.Tee(source, 3)
+		require.Len(t, seqs, 3)
+
+		for _, s := range seqs {
+			seqtest.AssertEqual(t, []int{1, 2, 3}, s)
+		}
+
+		assert.Equal(t, 3, *reads, "the source is read only once")
+	})
+
+	t.Run("single-use source", func(t *testing.T) {
+		ch := make(chan string, 2)
+		ch <- "a"
+		ch <- "b"
+		close(ch)
+
+		seqs := seq.Tee(seq.YieldChan(ch), 2)
+		seqtest.AssertEqual(t, []string{"a", "b"}, seqs[0])
+		seqtest.AssertEqual(t, []string{"a", "b"}, seqs[1])
+	})
+
+	t.Run("interleaved consumers", func(t *testing.T) {
+		seqs := seq.Tee(seq.Yield(1, 2, 3), 2)
+
+		got := seq.ToSlice(seq.SelectValues(seq.Zip(seqs[0], seqs[1]), func(a, b int) int { return a * b }))
+		assert.Equal(t, []int{1, 4, 9}, got)
+	})
+
+	t.Run("stopped consumer does not hold values", func(t *testing.T) {
+		source, reads := countingYield(1, 2, 3, 4)
+		seqs := seq.Tee(source, 2)
+
+		assert.Equal(t, []int{1}, limitedCollector(seqs[0], 1))
+		seqtest.AssertEqual(t, []int{1, 2, 3, 4}, seqs[1])
+		// a consumer can only be iterated once
+		seqtest.AssertEqual(t, nil, seqs[0])
+		assert.Equal(t, 4, *reads)
+	})
+
+	t.Run("source stops when all consumers stop", func(t *testing.T) {
+		stopped := false
+		source := func(yield func(int) bool) {
+			defer func() { stopped = true }()
+
+			for i := range 100 {
+				if !yield(i) {
+					return
+				}
+			}
+		}
+
+		seqs := seq.Tee(iter.Seq[int](source), 2)
+		limitedCollector(seqs[0], 2)
+		assert.False(t, stopped)
+
+		limitedCollector(seqs[1], 1)
+		assert.True(t, stopped)
+	})
+
+	t.Run("zero", func(t *testing.T) {
+		assert.Empty(t, seq.Tee(seq.Yield(1), 0))
+	})
+
+	assert.Panics(t, func() { seq.Tee(seq.Yield(1), -1) })
+}
+
+func Test_Broadcast(t *testing.T) {
+	t.Run("concurrent consumers", func(t *testing.T) {
+		source, reads := countingYield(seq.ToSlice(seq.Take(naturals(), 1000))...)
+		seqs := seq.Broadcast(source, 4, 8)
+
+		var wg sync.WaitGroup
+		sums := make([]int, len(seqs))
+
+		for i, s := range seqs {
+			wg.Go(func() {
+				sums[i] = seq.Sum(s)
+			})
+		}
+
+		wg.Wait()
+
+		for _, sum := range sums {
+			assert.Equal(t, 999*1000/2, sum)
+		}
+
+		assert.Equal(t, 1000, *reads)
+	})
+
+	t.Run("consumer stops early", func(t *testing.T) {
+		seqs := seq.Broadcast(seq.Take(naturals(), 100), 2, 0)
+
+		var wg sync.WaitGroup
+		var first []int
+		var second int
+
+		wg.Go(func() { first = limitedCollector(seqs[0], 3) })
+		wg.Go(func() { second = seq.Count(seqs[1]) })
+		wg.Wait()
+
+		assert.Equal(t, []int{0, 1, 2}, first)
+		assert.Equal(t, 100, second)
+	})
+
+	t.Run("producer stops when all consumers stop", func(t *testing.T) {
+		seqs := seq.Broadcast(naturals(), 2, 1)
+
+		var wg sync.WaitGroup
+		for _, s := range seqs {
+			wg.Go(func() { limitedCollector(s, 5) })
+		}
+
+		wg.Wait()
+	})
+
+	t.Run("panic is raised in consumers", func(t *testing.T) {
+		source := func(yield func(int) bool) {
+			yield(1)
+			panic("boom")
+		}
+
+		seqs := seq.Broadcast(iter.Seq[int](source), 2, 1)
+
+		var wg sync.WaitGroup
+		panics := make([]any, len(seqs))
+
+		for i, s := range seqs {
+			wg.Go(func() {
+				defer func() { panics[i] = recover() }()
+				seq.Count(s)
+			})
+		}
+
+		wg.Wait()
+		assert.Equal(t, []any{"boom", "boom"}, panics)
+	})
+
+	assert.Panics(t, func() { seq.Broadcast(seq.Yield(1), -1, 1) })
+	assert.Panics(t, func() { seq.Broadcast(seq.Yield(1), 1, -1) })
+}
@@ -0,0 +1,50 @@
+package seq
+
+import (
+	"iter"
+)
+
+// Pair is a key-value pair, used to store the values of a key-value sequence in a single value.
+type Pair[K, V any] struct {
+	Key   K
+	Value V
+}
+
+// Triple is a tuple of three values.
+type Triple[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+// CollectPairs collects the key-value pairs of a sequence into a slice.
+func CollectPairs[K, V any](seq iter.Seq2[K, V]) []Pair[K, V] {
+	var pairs []Pair[K, V]
+	for k, v := range seq {
+		pairs = append(pairs, Pair[K, V]{k, v})
+	}
+
+	return pairs
+}
+
+// FromPairs returns a key-value sequence from a sequence of pairs.
+func FromPairs[K, V any](seq iter.Seq[Pair[K, V]]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for p := range seq {
+			if !yield(p.Key, p.Value) {
+				return
+			}
+		}
+	}
+}
+
+// ToPairs returns a sequence of pairs from a key-value sequence.
+func ToPairs[K, V any](seq iter.Seq2[K, V]) iter.Seq[Pair[K, V]] {
+	return func(yield func(Pair[K, V]) bool) {
+		for k, v := range seq {
+			if !yield(Pair[K, V]{k, v}) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package seq_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/arielsrv/go-seq"
+	"github.com/arielsrv/go-seq/internal/seqtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ToPairs(t *testing.T) {
+	pairs := seq.ToPairs(seq.Zip(seq.Yield("a", "b"), seq.Yield(1, 2)))
+
+	seqtest.AssertEqual(t, []seq.Pair[string, int]{{"a", 1}, {"b", 2}}, pairs)
+	seqtest.AssertEqual(t, nil, seq.ToPairs(seq.Empty2[string, int]()))
+}
+
+func Test_FromPairs(t *testing.T) {
+	pairs := seq.Yield(seq.Pair[string, int]{"a", 1}, seq.Pair[string, int]{"b", 2})
+
+	seqtest.AssertEqual2(t, []seqtest.KeyValuePair[string, int]{{"a", 1}, {"b", 2}}, seq.FromPairs(pairs))
+	seqtest.AssertEqual2(t, nil, seq.FromPairs(seq.Empty[seq.Pair[string, int]]()))
+}
+
+func Test_CollectPairs(t *testing.T) {
+	pairs := seq.CollectPairs(seq.Zip(seq.Yield("a", "b"), seq.Yield(1, 2)))
+	assert.Equal(t, []seq.Pair[string, int]{{"a", 1}, {"b", 2}}, pairs)
+
+	assert.Nil(t, seq.CollectPairs(seq.Empty2[string, int]()))
+}
+
+func Test_Pairs_RoundTrip(t *testing.T) {
+	source := seq.Zip(seq.Yield("c", "a", "b"), seq.Yield(3, 1, 2))
+
+	t.Run("chunk", func(t *testing.T) {
+		chunks := seq.ToSlice(seq.Chunk(seq.ToPairs(source), 2))
+		assert.Len(t, chunks, 2)
+
+		got := seq.FromPairs(seq.SelectMany(slices.Values(chunks), slices.Values))
+		seqtest.AssertEqual2(t, []seqtest.KeyValuePair[string, int]{{"c", 3}, {"a", 1}, {"b", 2}}, got)
+	})
+
+	t.Run("sorted", func(t *testing.T) {
+		sorted := seq.SortedBy(seq.ToPairs(source), func(p seq.Pair[string, int]) int { return p.Value })
+
+		got := seq.FromPairs(slices.Values(sorted))
+		seqtest.AssertEqual2(t, []seqtest.KeyValuePair[string, int]{{"a", 1}, {"b", 2}, {"c", 3}}, got)
+	})
+
+	t.Run("channel", func(t *testing.T) {
+		ch := make(chan seq.Pair[string, int], 3)
+		for p := range seq.ToPairs(source) {
+			ch <- p
+		}
+		close(ch)
+
+		got := seq.FromPairs(seq.YieldChan(ch))
+		seqtest.AssertEqual2(t, []seqtest.KeyValuePair[string, int]{{"c", 3}, {"a", 1}, {"b", 2}}, got)
+	})
+}